@@ -0,0 +1,172 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"reflect"
+)
+
+// ErrOverflow notifies the caller that the result of an operation cannot be represented by the target type.
+var ErrOverflow = fmt.Errorf("value overflows type")
+
+// Add returns the sum of a and b. ErrOverflow is returned when the sum cannot be represented by T.
+func Add[T Number](a, b T) (T, error) {
+	an, am := magnitude(a)
+	bn, bm := magnitude(b)
+
+	var (
+		neg bool
+		mag uint64
+	)
+
+	switch {
+	case an == bn:
+		var carry uint64
+		mag, carry = bits.Add64(am, bm, 0)
+		if carry != 0 {
+			return 0, ErrOverflow
+		}
+
+		neg = an
+	case am >= bm:
+		neg, mag = an, am-bm
+	default:
+		neg, mag = bn, bm-am
+	}
+
+	return checked[T](neg, mag)
+}
+
+// Mul returns the product of a and b. ErrOverflow is returned when the product cannot be represented by T.
+func Mul[T Number](a, b T) (T, error) {
+	an, am := magnitude(a)
+	bn, bm := magnitude(b)
+
+	hi, lo := bits.Mul64(am, bm)
+	if hi != 0 {
+		return 0, ErrOverflow
+	}
+
+	return checked[T](an != bn && lo != 0, lo)
+}
+
+// Scale returns value * numerator / denominator, truncated towards zero. The intermediate product is computed without
+// loss of precision, so only the final result needs to be representable by T. ErrOverflow is returned when it is not.
+func Scale[T Number](value T, numerator, denominator int64) (T, error) {
+	if denominator == 0 {
+		return 0, fmt.Errorf("scale by zero denominator")
+	}
+
+	neg, mag := magnitude(value)
+
+	result := new(big.Int).SetUint64(mag)
+	if neg {
+		result.Neg(result)
+	}
+
+	result.Mul(result, big.NewInt(numerator))
+	result.Quo(result, big.NewInt(denominator))
+
+	neg = result.Sign() < 0
+	result.Abs(result)
+	if !result.IsUint64() {
+		return 0, ErrOverflow
+	}
+
+	return checked[T](neg, result.Uint64())
+}
+
+// checked converts a sign and magnitude back into T, returning ErrOverflow when it falls outside the range of T.
+func checked[T Number](neg bool, mag uint64) (T, error) {
+	value, ok := fromMagnitude[T](neg, mag)
+	if !ok {
+		return 0, ErrOverflow
+	}
+
+	return value, nil
+}
+
+// fromMagnitude is the inverse of magnitude. It reports false when the value falls outside the range of T.
+func fromMagnitude[T Number](neg bool, mag uint64) (T, bool) {
+	signed, max := limits[T]()
+
+	switch {
+	case !neg && mag > max:
+		return 0, false
+	case neg && !signed && mag > 0:
+		return 0, false
+	case neg && mag > max+1:
+		return 0, false
+	}
+
+	value := T(mag)
+	if neg {
+		value = -value
+	}
+
+	return value, true
+}
+
+// limits reports whether T is signed along with the largest value T can represent.
+func limits[T Number]() (signed bool, max uint64) {
+	var zero T
+	size := uint(reflect.TypeOf(zero).Bits())
+	signed = zero-1 < zero
+
+	if signed {
+		return true, 1<<(size-1) - 1
+	}
+
+	return false, 1<<size - 1
+}
+
+// Convert returns the value as a To, allowing quantities to be exchanged between signed and unsigned types (for
+// example, converting the uint64 byte counts reported by runtime.MemStats to a data.Size). ErrNegative is returned when
+// a negative value is converted to an unsigned type, and ErrOverflow is returned when the value cannot otherwise be
+// represented.
+func Convert[To, From Number](value From) (To, error) {
+	neg, mag := magnitude(value)
+	if signed, _ := limits[To](); neg && !signed {
+		return 0, ErrNegative
+	}
+
+	return checked[To](neg, mag)
+}
+
+// ConvertUnit returns a copy of the Unit whose symbols are sized using To, allowing an existing Unit to format and
+// parse values of another type (for example, formatting uint64 counters using data.BinaryIEC). ErrOverflow is returned
+// when the size of a symbol cannot be represented by To.
+func ConvertUnit[To, From Number](u Unit[From]) (Unit[To], error) {
+	unit := make(Unit[To], 0, len(u))
+	for _, symbol := range u {
+		size, err := Convert[To](symbol.Size)
+		if err != nil {
+			return nil, err
+		}
+
+		unit = append(unit, Symbol[To]{size, symbol.Label, symbol.Names})
+	}
+
+	return unit, nil
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mjpitz/units"
+)
+
+func TestAdd(t *testing.T) {
+	testCases := []struct {
+		a, b     int64
+		expected int64
+		overflow bool
+	}{
+		{1, 2, 3, false},
+		{-1, -2, -3, false},
+		{5, -7, -2, false},
+		{-5, 7, 2, false},
+		{math.MaxInt64, 0, math.MaxInt64, false},
+		{math.MaxInt64, 1, 0, true},
+		{math.MinInt64, -1, 0, true},
+		{math.MinInt64, math.MaxInt64, -1, false},
+		{math.MaxInt64, math.MaxInt64, 0, true},
+		{math.MinInt64, math.MinInt64, 0, true},
+		{math.MinInt64 + 1, -1, math.MinInt64, false},
+	}
+
+	for _, testCase := range testCases {
+		sum, err := units.Add(testCase.a, testCase.b)
+		if testCase.overflow {
+			require.ErrorIs(t, err, units.ErrOverflow, "%d + %d", testCase.a, testCase.b)
+			continue
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, testCase.expected, sum, "%d + %d", testCase.a, testCase.b)
+	}
+
+	_, err := units.Add[int8](100, 28)
+	require.ErrorIs(t, err, units.ErrOverflow)
+}
+
+func TestMul(t *testing.T) {
+	testCases := []struct {
+		a, b     int64
+		expected int64
+		overflow bool
+	}{
+		{3, 4, 12, false},
+		{-3, 4, -12, false},
+		{-3, -4, 12, false},
+		{0, math.MinInt64, 0, false},
+		{math.MinInt64, 1, math.MinInt64, false},
+		{math.MinInt64, -1, 0, true},
+		{-1, math.MinInt64, 0, true},
+		{1 << 32, 1 << 31, 0, true},
+		{1 << 32, -(1 << 31), math.MinInt64, false},
+		{1 << 40, 1 << 40, 0, true},
+	}
+
+	for _, testCase := range testCases {
+		product, err := units.Mul(testCase.a, testCase.b)
+		if testCase.overflow {
+			require.ErrorIs(t, err, units.ErrOverflow, "%d * %d", testCase.a, testCase.b)
+			continue
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, testCase.expected, product, "%d * %d", testCase.a, testCase.b)
+	}
+}
+
+func TestScale(t *testing.T) {
+	scaled, err := units.Scale(int64(1000), 80, 100)
+	require.NoError(t, err)
+	require.Equal(t, int64(800), scaled)
+
+	scaled, err = units.Scale(int64(math.MaxInt64), 3, 4)
+	require.NoError(t, err)
+	require.Equal(t, int64(6917529027641081855), scaled)
+
+	scaled, err = units.Scale(int64(-7), 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(-3), scaled)
+
+	scaled, err = units.Scale(int64(math.MinInt64), 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(math.MinInt64), scaled)
+
+	_, err = units.Scale(int64(math.MaxInt64), 2, 1)
+	require.ErrorIs(t, err, units.ErrOverflow)
+
+	_, err = units.Scale(int64(math.MinInt64), -1, 1)
+	require.ErrorIs(t, err, units.ErrOverflow)
+
+	_, err = units.Scale(int64(1), 1, 0)
+	require.Error(t, err)
+}
+
+func TestConvert(t *testing.T) {
+	unsigned, err := units.Convert[uint64](int64(math.MaxInt64))
+	require.NoError(t, err)
+	require.Equal(t, uint64(math.MaxInt64), unsigned)
+
+	_, err = units.Convert[uint64](int64(-1))
+	require.ErrorIs(t, err, units.ErrNegative)
+
+	_, err = units.Convert[int64](uint64(math.MaxUint64))
+	require.ErrorIs(t, err, units.ErrOverflow)
+
+	signed, err := units.Convert[int8](uint32(127))
+	require.NoError(t, err)
+	require.Equal(t, int8(127), signed)
+
+	_, err = units.Convert[int8](128)
+	require.ErrorIs(t, err, units.ErrOverflow)
+
+	signed, err = units.Convert[int8](-128)
+	require.NoError(t, err)
+	require.Equal(t, int8(-128), signed)
+}
+
+func TestConvertUnit(t *testing.T) {
+	unsigned, err := units.ConvertUnit[uint64](binary)
+	require.NoError(t, err)
+	require.Len(t, unsigned, len(binary))
+	require.Equal(t, "17179869183GiB1023MiB1023KiB1023B", unsigned.Format(math.MaxUint64))
+
+	_, err = units.ConvertUnit[uint16](binary)
+	require.ErrorIs(t, err, units.ErrOverflow)
+}
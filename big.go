@@ -0,0 +1,332 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units
+
+import (
+	"math/big"
+	"strconv"
+)
+
+// BigSymbol is like Symbol, but holds a size that may exceed the range of the integer types supported by Number.
+type BigSymbol struct {
+	Size  *big.Int
+	Label []string
+	Names []string
+}
+
+// BigUnit is like Unit, but works with arbitrary precision integers. This allows values that would overflow the base
+// unit of an integer type to be formatted and parsed (for example, light-years measured in nanometers). Like Unit,
+// symbols must be sorted in ascending order by size.
+type BigUnit []BigSymbol
+
+// NewBigUnit converts the symbols of the provided Unit to a BigUnit, appending any additional symbols. Additional
+// symbols must be larger than every symbol in the Unit (see BigUnit.Validate). Their sizes are copied so that later
+// changes to the provided values don't affect the BigUnit.
+func NewBigUnit[T Number](u Unit[T], symbols ...BigSymbol) BigUnit {
+	unit := make(BigUnit, 0, len(u)+len(symbols))
+	for _, symbol := range u {
+		unit = append(unit, BigSymbol{ToBig(symbol.Size), symbol.Label, symbol.Names})
+	}
+
+	for _, symbol := range symbols {
+		if symbol.Size != nil {
+			symbol.Size = new(big.Int).Set(symbol.Size)
+		}
+
+		unit = append(unit, symbol)
+	}
+
+	return unit
+}
+
+func (u BigUnit) count() int {
+	return len(u)
+}
+
+func (u BigUnit) symbol(i int) (label, names []string) {
+	return u[i].Label, u[i].Names
+}
+
+func (u BigUnit) same(i, j int) bool {
+	if u[i].Size == nil || u[j].Size == nil {
+		return u[i].Size == u[j].Size
+	}
+
+	return u[i].Size.Cmp(u[j].Size) == 0
+}
+
+func (u BigUnit) positive(i int) bool {
+	return u[i].Size != nil && u[i].Size.Sign() > 0
+}
+
+func (u BigUnit) less(i, j int) bool {
+	return u[i].Size != nil && u[j].Size != nil && u[i].Size.Cmp(u[j].Size) < 0
+}
+
+// Validate ensures the BigUnit is well-formed using the same rules as Unit.Validate. Symbols without a size are
+// reported as having an invalid size.
+func (u BigUnit) Validate() error {
+	return validate(u)
+}
+
+// Format converts the provided value to a human-readable string using the same options as Unit.Format.
+func (u BigUnit) Format(value *big.Int, opts ...Option) (str string) {
+	if len(u) == 0 {
+		return ""
+	}
+
+	options := apply(opts)
+	if value == nil || value.Sign() == 0 {
+		return renderZero(u, options)
+	}
+
+	switch {
+	case value.Sign() < 0:
+		str = "-"
+	case options.ExplicitPlus:
+		str = "+"
+	}
+
+	mag := new(big.Int).Abs(value)
+	switch options.Style {
+	case StyleLargest:
+		return str + u.single(mag, u.carry(mag, u.largest(mag), options), options)
+	case StyleFixed:
+		i := find(u, options.Symbol)
+		if i < 0 {
+			i = u.largest(mag)
+		}
+
+		return str + u.single(mag, i, options)
+	}
+
+	if options.MaxComponents > 0 {
+		mag = u.limit(mag, options.MaxComponents)
+	}
+
+	// verbose and spaced components are separated by a space (for example, "1 gibibyte 512 mebibytes")
+	first := len(str)
+	separate := func() {
+		if (options.Verbose || options.Locale != nil && options.Locale.Space) && len(str) > first {
+			str += " "
+		}
+	}
+
+	quo := new(big.Int)
+	for i := len(u); mag.Sign() > 0 && i > 1; i-- {
+		size := u[i-1].Size
+		if mag.Cmp(size) >= 0 {
+			separate()
+			quo.QuoRem(mag, size, mag)
+			str += render(u, quo.String(), i-1, options)
+		}
+	}
+
+	if mag.Sign() > 0 {
+		separate()
+		str += u.single(mag, 0, options)
+	}
+
+	return str
+}
+
+// limit rounds the magnitude so that it can be represented using at most n components. See Unit.limit.
+func (u BigUnit) limit(mag *big.Int, n int) *big.Int {
+	for {
+		components := 0
+		rem := new(big.Int).Set(mag)
+		for i := len(u) - 1; rem.Sign() > 0 && i >= 0; i-- {
+			size := u[i].Size
+			if rem.Cmp(size) < 0 && i > 0 {
+				continue
+			}
+
+			components++
+			if components == n {
+				if i == 0 {
+					return mag
+				}
+
+				// only the remainder is rounded since larger symbols need not be multiples of this one
+				rounded := roundToBig(rem, size)
+				rounded.Add(rounded, mag).Sub(rounded, rem)
+				if rounded.Cmp(mag) == 0 {
+					return mag
+				}
+
+				mag = rounded
+				break
+			}
+
+			rem.Rem(rem, size)
+		}
+
+		if components < n {
+			return mag
+		}
+	}
+}
+
+// roundToBig rounds the magnitude to the nearest multiple of size, rounding half away from zero.
+func roundToBig(mag, size *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(mag, size, new(big.Int))
+	if r.Cmp(new(big.Int).Sub(size, r)) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+
+	return q.Mul(q, size)
+}
+
+// single renders the magnitude as a decimal number of the i-th symbol.
+func (u BigUnit) single(mag *big.Int, i int, options Options) string {
+	return render(u, u.number(mag, i, options), i, options)
+}
+
+// number renders the magnitude as a decimal number of the i-th symbol without its label.
+func (u BigUnit) number(mag *big.Int, i int, options Options) string {
+	size := u[i].Size
+	if options.Format != 'f' {
+		value := new(big.Float).SetPrec(53).SetRat(new(big.Rat).SetFrac(mag, size))
+		return value.Text(options.Format, options.Precision)
+	}
+
+	whole, rem := new(big.Int).QuoRem(mag, size, new(big.Int))
+	frac, _ := new(big.Rat).SetFrac(rem, size).Float64()
+
+	// fractions are rendered as "0.ddd" unless rounding carries them over to "1.ddd"
+	str := strconv.FormatFloat(frac, 'f', options.Precision, 64)
+	if str[0] == '1' {
+		whole.Add(whole, big.NewInt(1))
+	}
+
+	return whole.String() + str[1:]
+}
+
+// carry moves up from the i-th symbol while the rounded magnitude reaches the size of the next symbol. See Unit.carry.
+func (u BigUnit) carry(mag *big.Int, i int, options Options) int {
+	for ; i+1 < len(u); i++ {
+		if !carries(u.number(mag, i, options), new(big.Rat).SetInt(u[i].Size), new(big.Rat).SetInt(u[i+1].Size)) {
+			break
+		}
+	}
+
+	return i
+}
+
+// largest returns the index of the largest symbol the magnitude fills. When the magnitude is smaller than every symbol,
+// the smallest symbol is returned.
+func (u BigUnit) largest(mag *big.Int) int {
+	for i := len(u) - 1; i > 0; i-- {
+		if mag.Cmp(u[i].Size) >= 0 {
+			return i
+		}
+	}
+
+	return 0
+}
+
+// Parse converts the provided string value to its equivalent arbitrary precision representation using the same syntax
+// and options as Unit.Parse. Since values are not bound by the range of an integer type, ErrOverflow is never returned.
+func (u BigUnit) Parse(val string, opts ...Option) (*big.Int, error) {
+	options := apply(opts)
+
+	idx, err := newIndex(u, options)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := newScanner(idx, val, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		total = new(big.Rat)
+		// inexact tracks the last component that is not a whole number so it can be reported in strict mode
+		inexact component
+	)
+
+	for s.more() {
+		c, err := s.next()
+		if err != nil {
+			return nil, err
+		}
+
+		value := c.value(u[c.symbol].Size)
+		if !value.IsInt() {
+			inexact = c
+		}
+
+		total.Add(total, value)
+	}
+
+	whole, rem := new(big.Int).QuoRem(total.Num(), total.Denom(), new(big.Int))
+	if rem.Sign() != 0 {
+		if options.Strict {
+			return nil, s.fail(inexact, ErrInexact)
+		}
+
+		if roundUp(s.neg, whole.Bit(0) == 1, rem, total.Denom(), options.Rounding) {
+			whole.Add(whole, big.NewInt(1))
+		}
+	}
+
+	if s.neg {
+		whole.Neg(whole)
+	}
+
+	return whole, nil
+}
+
+// As returns the value as a multiple of the symbol with the provided label (for example, the number of light-years in a
+// length). ErrUnrecognizedSymbol is returned when no symbol uses the label.
+func (u BigUnit) As(value *big.Int, label string) (float64, error) {
+	i := find(u, label)
+	if i < 0 {
+		return 0, ErrUnrecognizedSymbol
+	}
+
+	f, _ := new(big.Rat).SetFrac(value, u[i].Size).Float64()
+	return f, nil
+}
+
+// ToBig converts the provided value to an arbitrary precision integer.
+func ToBig[T Number](value T) *big.Int {
+	neg, mag := magnitude(value)
+
+	result := new(big.Int).SetUint64(mag)
+	if neg {
+		result.Neg(result)
+	}
+
+	return result
+}
+
+// FromBig converts the provided arbitrary precision integer to T. ErrOverflow is returned when the value cannot be
+// represented by T.
+func FromBig[T Number](value *big.Int) (T, error) {
+	mag := new(big.Int).Abs(value)
+	if !mag.IsUint64() {
+		return 0, ErrOverflow
+	}
+
+	return checked[T](value.Sign() < 0, mag.Uint64())
+}
@@ -0,0 +1,182 @@
+package units_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mjpitz/units"
+)
+
+var bigBinary = units.NewBigUnit(binary,
+	units.BigSymbol{new(big.Int).Lsh(big.NewInt(1), 70), []string{"ZiB"}, []string{"zebibyte"}},
+	units.BigSymbol{new(big.Int).Lsh(big.NewInt(1), 80), []string{"YiB"}, []string{"yobibyte"}},
+)
+
+func pow2(n uint) *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), n)
+}
+
+func sum(values ...*big.Int) *big.Int {
+	result := new(big.Int)
+	for _, value := range values {
+		result.Add(result, value)
+	}
+
+	return result
+}
+
+func TestBigUnit_Format(t *testing.T) {
+	value := sum(pow2(80), pow2(79), pow2(30))
+	half := sum(pow2(80), pow2(79))
+
+	testCases := []struct {
+		value    *big.Int
+		opts     []units.Option
+		expected string
+	}{
+		{value, nil, "1YiB512ZiB1GiB"},
+		{new(big.Int).Neg(value), nil, "-1YiB512ZiB1GiB"},
+		{value, []units.Option{units.ExplicitPlus()}, "+1YiB512ZiB1GiB"},
+		{half, []units.Option{units.Largest()}, "1.5YiB"},
+		{half, []units.Option{units.Largest(), units.Precision(3)}, "1.500YiB"},
+		{half, []units.Option{units.Largest(), units.Format('e'), units.Precision(1)}, "1.5e+00YiB"},
+		{new(big.Int).Sub(pow2(80), big.NewInt(1)), []units.Option{units.Largest(), units.Precision(2)}, "1.00YiB"},
+		{value, []units.Option{units.Fixed("ZiB"), units.Precision(12)}, "1536.000000000001ZiB"},
+		{value, []units.Option{units.Fixed("ZiB"), units.Precision(0)}, "1536ZiB"},
+		{value, []units.Option{units.MaxComponents(2)}, "1YiB512ZiB"},
+		{sum(pow2(80), pow2(70), pow2(69)), []units.Option{units.MaxComponents(2)}, "1YiB2ZiB"},
+		{value, []units.Option{units.Verbose()}, "1 yobibyte 512 zebibytes 1 gibibyte"},
+		{half, []units.Option{units.German, units.Largest()}, "1,5 YiB"},
+		{big.NewInt(1<<30 + 512<<20), nil, "1GiB512MiB"},
+		{big.NewInt(0), nil, "0B"},
+		{nil, []units.Option{units.ZeroPlaceholder("-")}, "-"},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, bigBinary.Format(testCase.value, testCase.opts...))
+	}
+
+	require.Equal(t, "", units.BigUnit{}.Format(value))
+}
+
+func TestBigUnit_Parse(t *testing.T) {
+	testCases := []struct {
+		input    string
+		opts     []units.Option
+		expected *big.Int
+	}{
+		{"1YiB512ZiB1GiB", nil, sum(pow2(80), pow2(79), pow2(30))},
+		{"1.5 yobibytes", nil, sum(pow2(80), pow2(79))},
+		{"-1YiB", nil, new(big.Int).Neg(pow2(80))},
+		{"1e6YiB", nil, new(big.Int).Mul(pow2(80), big.NewInt(1000000))},
+		{"1.5B", nil, big.NewInt(2)},
+		{"1.5B", []units.Option{units.Rounding(units.RoundTruncate)}, big.NewInt(1)},
+		{"-1.5B", []units.Option{units.Rounding(units.RoundFloor)}, big.NewInt(-2)},
+		{"1,5 YiB", []units.Option{units.German}, sum(pow2(80), pow2(79))},
+		{"1yib", []units.Option{units.IgnoreCase()}, pow2(80)},
+		{"0", nil, big.NewInt(0)},
+		{"", nil, big.NewInt(0)},
+	}
+
+	for _, testCase := range testCases {
+		parsed, err := bigBinary.Parse(testCase.input, testCase.opts...)
+		require.NoError(t, err, testCase.input)
+		require.Equal(t, 0, testCase.expected.Cmp(parsed), "%s: %v", testCase.input, parsed)
+	}
+
+	_, err := bigBinary.Parse("1.5B", units.Strict())
+	require.ErrorIs(t, err, units.ErrInexact)
+	require.EqualError(t, err, `value cannot be represented exactly "1.5" at offset 0 of "1.5B"`)
+
+	_, err = bigBinary.Parse("1XiB")
+	require.ErrorIs(t, err, units.ErrUnrecognizedSymbol)
+
+	// values round trip through Format
+	for _, value := range []*big.Int{sum(pow2(80), pow2(79), pow2(30), big.NewInt(7)), new(big.Int).Neg(pow2(100))} {
+		parsed, err := bigBinary.Parse(bigBinary.Format(value))
+		require.NoError(t, err)
+		require.Equal(t, 0, value.Cmp(parsed))
+	}
+}
+
+func TestBigUnit_As(t *testing.T) {
+	value := sum(pow2(80), pow2(79))
+
+	as, err := bigBinary.As(value, "YiB")
+	require.NoError(t, err)
+	require.Equal(t, 1.5, as)
+
+	as, err = bigBinary.As(value, "GiB")
+	require.NoError(t, err)
+	require.Equal(t, 1.5*(1<<50), as)
+
+	_, err = bigBinary.As(value, "DNE")
+	require.ErrorIs(t, err, units.ErrUnrecognizedSymbol)
+}
+
+func TestBig(t *testing.T) {
+	for _, value := range []int64{0, 1, -1, math.MaxInt64, math.MinInt64} {
+		converted, err := units.FromBig[int64](units.ToBig(value))
+		require.NoError(t, err)
+		require.Equal(t, value, converted)
+	}
+
+	_, err := units.FromBig[int64](pow2(63))
+	require.ErrorIs(t, err, units.ErrOverflow)
+
+	converted, err := units.FromBig[int64](new(big.Int).Neg(pow2(63)))
+	require.NoError(t, err)
+	require.Equal(t, int64(math.MinInt64), converted)
+
+	_, err = units.FromBig[int8](big.NewInt(128))
+	require.ErrorIs(t, err, units.ErrOverflow)
+
+	_, err = units.FromBig[int64](pow2(80))
+	require.ErrorIs(t, err, units.ErrOverflow)
+}
+
+func TestBigUnit_Validate(t *testing.T) {
+	require.NoError(t, bigBinary.Validate())
+	require.ErrorIs(t, units.BigUnit{}.Validate(), units.ErrEmptyUnit)
+
+	testCases := []struct {
+		unit  units.BigUnit
+		index int
+		label string
+		cause error
+	}{
+		{units.BigUnit{{nil, []string{"N"}, nil}}, 0, "N", units.ErrInvalidSize},
+		{units.BigUnit{{big.NewInt(0), []string{"Z"}, nil}}, 0, "Z", units.ErrInvalidSize},
+		{units.NewBigUnit(binary, units.BigSymbol{pow2(10), []string{"K"}, nil}), len(binary), "K", units.ErrUnsorted},
+		{units.NewBigUnit(binary, units.BigSymbol{pow2(70), nil, nil}), len(binary), "", units.ErrMissingLabel},
+		{units.NewBigUnit(binary, units.BigSymbol{pow2(70), []string{"GiB"}, nil}), len(binary), "GiB", units.ErrDuplicateLabel},
+		{units.NewBigUnit(binary, units.BigSymbol{pow2(70), []string{"2Z"}, nil}), len(binary), "2Z", units.ErrUnparsableLabel},
+	}
+
+	for _, testCase := range testCases {
+		err := testCase.unit.Validate()
+		require.ErrorIs(t, err, testCase.cause)
+
+		var verr *units.ValidationError
+		require.ErrorAs(t, err, &verr)
+		require.Len(t, verr.Errors, 1, err.Error())
+
+		var serr *units.SymbolError
+		require.ErrorAs(t, verr.Errors[0], &serr)
+		require.Equal(t, testCase.index, serr.Index)
+		require.Equal(t, testCase.label, serr.Label)
+	}
+}
+
+func TestNewBigUnit(t *testing.T) {
+	size := pow2(70)
+	unit := units.NewBigUnit(binary, units.BigSymbol{size, []string{"ZiB"}, []string{"zebibyte"}})
+
+	// sizes are copied, so modifying them later leaves the unit untouched
+	size.Lsh(size, 10)
+	require.Equal(t, 0, pow2(70).Cmp(unit[len(binary)].Size))
+	require.Equal(t, "1ZiB", unit.Format(pow2(70)))
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units
+
+// Codec is the compiled form of a Unit. Compiling a Unit builds its label index once, allowing repeated calls to Parse
+// to avoid rebuilding it every time. Codecs are immutable once compiled, making them safe for concurrent use.
+type Codec[T Number] struct {
+	unit    Unit[T]
+	options Options
+	index   *index
+	// folded supports parsing without regard to case and is nil when the unit contains labels that collide
+	folded    *index
+	ambiguity error
+}
+
+// Compile builds a Codec for the provided Unit. The provided options are used as the defaults for every call to Format
+// and Parse made through the Codec. An error is returned when the Unit fails validation (see Unit.Validate), or when
+// IgnoreCase is configured and the Unit contains labels that only differ by case (see CollisionError).
+func Compile[T Number](u Unit[T], opts ...Option) (*Codec[T], error) {
+	if err := u.Validate(); err != nil {
+		return nil, err
+	}
+
+	options := apply(opts)
+
+	unit := make(Unit[T], len(u))
+	copy(unit, u)
+
+	exact, folded := options, options
+	exact.IgnoreCase, folded.IgnoreCase = false, true
+
+	index, _ := newIndex(unit, exact)
+	foldedIndex, err := newIndex(unit, folded)
+	if err != nil && options.IgnoreCase {
+		return nil, err
+	}
+
+	return &Codec[T]{unit, options, index, foldedIndex, err}, nil
+}
+
+// MustCompile is like Compile, but panics if the Unit cannot be compiled. It simplifies the initialization of global
+// variables holding compiled codecs.
+func MustCompile[T Number](u Unit[T], opts ...Option) *Codec[T] {
+	codec, err := Compile(u, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return codec
+}
+
+// Unit returns a copy of the Unit the Codec was compiled from.
+func (c *Codec[T]) Unit() Unit[T] {
+	unit := make(Unit[T], len(c.unit))
+	copy(unit, c.unit)
+	return unit
+}
+
+// Format converts the provided value to a human-readable string. See Unit.Format for more information.
+func (c *Codec[T]) Format(value T, opts ...Option) string {
+	return c.unit.format(value, c.options.with(opts))
+}
+
+// Parse converts the provided string value to its equivalent numeric representation. See Unit.Parse for more
+// information. Parsing with a Locale other than the one the Codec was compiled with rebuilds the label index on every
+// call.
+func (c *Codec[T]) Parse(val string, opts ...Option) (T, error) {
+	options := c.options.with(opts)
+
+	idx, err := c.lookup(options)
+	if err != nil {
+		return 0, err
+	}
+
+	return parse(c.unit, idx, val, options)
+}
+
+// ParseExpr evaluates the provided arithmetic expression. See Unit.ParseExpr for more information.
+func (c *Codec[T]) ParseExpr(val string, opts ...Option) (T, error) {
+	options := c.options.with(opts)
+
+	idx, err := c.lookup(options)
+	if err != nil {
+		return 0, err
+	}
+
+	return parseExpr(c.unit, idx, val, options)
+}
+
+// lookup returns the index matching the provided options.
+func (c *Codec[T]) lookup(options Options) (*index, error) {
+	switch {
+	case options.Locale != c.options.Locale:
+		// the indexes were built for a different locale
+		return newIndex(c.unit, options)
+	case options.IgnoreCase && c.folded == nil:
+		return nil, c.ambiguity
+	case options.IgnoreCase:
+		return c.folded, nil
+	}
+
+	return c.index, nil
+}
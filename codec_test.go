@@ -0,0 +1,121 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mjpitz/units"
+)
+
+var binary = units.Unit[int64]{
+	{1, []string{"B"}, []string{"byte"}},
+	{1 << 10, []string{"KiB"}, []string{"kibibyte"}},
+	{1 << 20, []string{"MiB"}, []string{"mebibyte"}},
+	{1 << 30, []string{"GiB"}, []string{"gibibyte"}},
+}
+
+func TestCompile(t *testing.T) {
+	_, err := units.Compile(units.Unit[int64]{})
+	require.ErrorIs(t, err, units.ErrEmptyUnit)
+
+	_, err = units.Compile(units.Unit[int64]{
+		{1, []string{"B"}, nil},
+		{1000, []string{"KB"}, nil},
+		{1024, []string{"KB"}, nil},
+	})
+	require.Error(t, err)
+
+	require.Panics(t, func() { units.MustCompile(units.Unit[int64]{}) })
+
+	codec := units.MustCompile(binary)
+	require.Equal(t, binary, codec.Unit())
+
+	// the codec must not be affected by changes to the original table
+	modified := codec.Unit()
+	modified[0].Size = 2
+	require.Equal(t, int64(1), codec.Unit()[0].Size)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for _, value := range []int64{1, 1 << 10, 3<<30 + 5<<20 + 7} {
+				size, err := codec.Parse(codec.Format(value))
+				require.NoError(t, err)
+				require.Equal(t, value, size)
+			}
+		}()
+	}
+	wg.Wait()
+
+	_, err = codec.Parse("1DNE")
+	require.ErrorIs(t, err, units.ErrUnrecognizedSymbol)
+}
+
+func TestCompile_Options(t *testing.T) {
+	codec := units.MustCompile(binary, units.IgnoreCase(), units.Largest())
+
+	parsed, err := codec.Parse("1gib512mib")
+	require.NoError(t, err)
+	require.Equal(t, int64(1<<30+512<<20), parsed)
+	require.Equal(t, "1.5GiB", codec.Format(parsed))
+	require.Equal(t, "1536MiB", codec.Format(parsed, units.Fixed("MiB")))
+
+	ambiguous := units.Unit[int64]{
+		{1, []string{"mB"}, nil},
+		{1000, []string{"MB"}, nil},
+	}
+
+	_, err = units.Compile(ambiguous, units.IgnoreCase())
+	require.ErrorIs(t, err, units.ErrAmbiguousLabel)
+
+	// case sensitive codecs only report collisions when parsing without regard to case
+	codec = units.MustCompile(ambiguous)
+
+	parsed, err = codec.Parse("1MB 1mB")
+	require.NoError(t, err)
+	require.Equal(t, int64(1001), parsed)
+
+	_, err = codec.Parse("1MB", units.IgnoreCase())
+	require.ErrorIs(t, err, units.ErrAmbiguousLabel)
+}
+
+func BenchmarkCodec_Parse(b *testing.B) {
+	codec := units.MustCompile(binary)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = codec.Parse("1GiB512MiB")
+	}
+}
+
+func BenchmarkUnit_Parse(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = binary.Parse("1GiB512MiB")
+	}
+}
@@ -21,6 +21,9 @@
 package data
 
 import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
 	"sort"
 
 	"github.com/mjpitz/units"
@@ -36,8 +39,20 @@ func (u Size) As(other Size) float64 {
 	return float64(u) / float64(other)
 }
 
+// Uint64 returns the size as an unsigned number of bytes. units.ErrNegative is returned when the size is negative.
+func (u Size) Uint64() (uint64, error) {
+	return units.Convert[uint64](u)
+}
+
+// FromUint64 converts an unsigned number of bytes to a Size. units.ErrOverflow is returned when the value exceeds the
+// range of Size.
+func FromUint64(value uint64) (Size, error) {
+	return units.Convert[Size](value)
+}
+
+// Set implements flag.Value, rounding fractions of a byte half to even, as units.Unit.Parse does.
 func (u *Size) Set(val string) error {
-	v, err := all.Parse(val)
+	v, err := codec.Parse(val)
 	if err != nil {
 		return err
 	}
@@ -50,6 +65,53 @@ func (u Size) String() string {
 	return Decimal.Format(u)
 }
 
+// Format implements fmt.Formatter. See units.FormatValue for the supported verbs.
+func (u Size) Format(f fmt.State, verb rune) {
+	units.FormatValue(f, verb, u, Decimal, constants)
+}
+
+// Scan implements fmt.Scanner, accepting the same values as Set.
+func (u *Size) Scan(state fmt.ScanState, verb rune) error {
+	v, err := units.ScanValue(state, verb, codec)
+	if err != nil {
+		return err
+	}
+
+	*u = v
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler using the same format as String.
+func (u Size) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting the same values as Set.
+func (u *Size) UnmarshalText(text []byte) error {
+	return u.Set(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting either a string (see Set) or a bare integer number of bytes.
+func (u *Size) UnmarshalJSON(data []byte) error {
+	return units.UnmarshalJSON(data, u, codec)
+}
+
+// MarshalYAML implements yaml.Marshaler, emitting the same format as String.
+func (u Size) MarshalYAML() (interface{}, error) {
+	return u.String(), nil
+}
+
+// UnmarshalYAML implements the obsolete form of yaml.Unmarshaler, accepting a string (see Set) or a bare integer number
+// of bytes. Errors don't report where the value appears in the document (see unitsyaml.Quantity).
+func (u *Size) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return units.UnmarshalYAML(unmarshal, u, codec)
+}
+
+// Value implements driver.Valuer, storing the value as an integer number of bytes (see units.SQL).
+func (u Size) Value() (driver.Value, error) {
+	return int64(u), nil
+}
+
 const (
 	Byte Size = 1
 
@@ -66,45 +128,107 @@ const (
 	Petabyte = Terabyte * 1000
 )
 
+// Exabyte returns the size of an exabyte. Exabyte and larger sizes are only declared for BigDecimal and BigBinaryIEC
+// since the largest of them exceed the range of Size. Each call returns a new *big.Int that the caller may modify.
+func Exabyte() *big.Int {
+	return new(big.Int).Mul(units.ToBig(Petabyte), big.NewInt(1000))
+}
+
+// Zettabyte returns the size of a zettabyte. See Exabyte.
+func Zettabyte() *big.Int {
+	return new(big.Int).Mul(Exabyte(), big.NewInt(1000))
+}
+
+// Yottabyte returns the size of a yottabyte. See Exabyte.
+func Yottabyte() *big.Int {
+	return new(big.Int).Mul(Zettabyte(), big.NewInt(1000))
+}
+
+// Exbibyte returns the size of an exbibyte. See Exabyte.
+func Exbibyte() *big.Int {
+	return new(big.Int).Lsh(units.ToBig(Pebibyte), 10)
+}
+
+// Zebibyte returns the size of a zebibyte. See Exabyte.
+func Zebibyte() *big.Int {
+	return new(big.Int).Lsh(Exbibyte(), 10)
+}
+
+// Yobibyte returns the size of a yobibyte. See Exabyte.
+func Yobibyte() *big.Int {
+	return new(big.Int).Lsh(Zebibyte(), 10)
+}
+
 var (
 	Decimal = units.Unit[Size]{
-		{Byte, []string{"B"}},
-		{Kilobyte, []string{"kB"}},
-		{Megabyte, []string{"MB"}},
-		{Gigabyte, []string{"GB"}},
-		{Terabyte, []string{"TB"}},
-		{Petabyte, []string{"PB"}},
+		{Byte, []string{"B"}, []string{"byte"}},
+		{Kilobyte, []string{"kB"}, []string{"kilobyte"}},
+		{Megabyte, []string{"MB"}, []string{"megabyte"}},
+		{Gigabyte, []string{"GB"}, []string{"gigabyte"}},
+		{Terabyte, []string{"TB"}, []string{"terabyte"}},
+		{Petabyte, []string{"PB"}, []string{"petabyte"}},
 	}
 
 	BinaryIEC = units.Unit[Size]{
-		{Byte, []string{"B"}},
-		{Kibibyte, []string{"KiB"}},
-		{Mebibyte, []string{"MiB"}},
-		{Gibibyte, []string{"GiB"}},
-		{Tebibyte, []string{"TiB"}},
-		{Pebibyte, []string{"PiB"}},
+		{Byte, []string{"B"}, []string{"byte"}},
+		{Kibibyte, []string{"KiB"}, []string{"kibibyte"}},
+		{Mebibyte, []string{"MiB"}, []string{"mebibyte"}},
+		{Gibibyte, []string{"GiB"}, []string{"gibibyte"}},
+		{Tebibyte, []string{"TiB"}, []string{"tebibyte"}},
+		{Pebibyte, []string{"PiB"}, []string{"pebibyte"}},
 	}
 
 	BinaryMemory = units.Unit[Size]{
-		{Byte, []string{"B"}},
-		{Kibibyte, []string{"KB"}}, // "kilobyte"
-		{Mebibyte, []string{"MB"}}, // "megabyte"
-		{Gibibyte, []string{"GB"}}, // "gigabyte"
-		{Tebibyte, []string{"TB"}}, // "terabyte"
+		{Byte, []string{"B"}, []string{"byte"}},
+		{Kibibyte, []string{"KB"}, []string{"kilobyte"}},
+		{Mebibyte, []string{"MB"}, []string{"megabyte"}},
+		{Gibibyte, []string{"GB"}, []string{"gigabyte"}},
+		{Tebibyte, []string{"TB"}, []string{"terabyte"}},
 	}
 
+	// BigDecimal extends Decimal with sizes beyond the range of Size.
+	BigDecimal = units.NewBigUnit(Decimal,
+		units.BigSymbol{Exabyte(), []string{"EB"}, []string{"exabyte"}},
+		units.BigSymbol{Zettabyte(), []string{"ZB"}, []string{"zettabyte"}},
+		units.BigSymbol{Yottabyte(), []string{"YB"}, []string{"yottabyte"}},
+	)
+
+	// BigBinaryIEC extends BinaryIEC with sizes beyond the range of Size.
+	BigBinaryIEC = units.NewBigUnit(BinaryIEC,
+		units.BigSymbol{Exbibyte(), []string{"EiB"}, []string{"exbibyte"}},
+		units.BigSymbol{Zebibyte(), []string{"ZiB"}, []string{"zebibyte"}},
+		units.BigSymbol{Yobibyte(), []string{"YiB"}, []string{"yobibyte"}},
+	)
+
 	all = units.Unit[Size]{
-		{Byte, []string{"B"}},
-		{Kilobyte, []string{"kB"}},
-		{Kibibyte, []string{"KiB"}},
-		{Megabyte, []string{"MB"}},
-		{Mebibyte, []string{"MiB"}},
-		{Gigabyte, []string{"GB"}},
-		{Gibibyte, []string{"GiB"}},
-		{Terabyte, []string{"TB"}},
-		{Tebibyte, []string{"TiB"}},
-		{Petabyte, []string{"PB"}},
-		{Pebibyte, []string{"PiB"}},
+		{Byte, []string{"B"}, []string{"byte"}},
+		{Kilobyte, []string{"kB"}, []string{"kilobyte"}},
+		{Kibibyte, []string{"KiB"}, []string{"kibibyte"}},
+		{Megabyte, []string{"MB"}, []string{"megabyte"}},
+		{Mebibyte, []string{"MiB"}, []string{"mebibyte"}},
+		{Gigabyte, []string{"GB"}, []string{"gigabyte"}},
+		{Gibibyte, []string{"GiB"}, []string{"gibibyte"}},
+		{Terabyte, []string{"TB"}, []string{"terabyte"}},
+		{Tebibyte, []string{"TiB"}, []string{"tebibyte"}},
+		{Petabyte, []string{"PB"}, []string{"petabyte"}},
+		{Pebibyte, []string{"PiB"}, []string{"pebibyte"}},
+	}
+
+	codec *units.Codec[Size]
+
+	// constants maps sizes to the names of the constants declaring them for rendering Go syntax
+	constants = map[Size]string{
+		Byte:     "data.Byte",
+		Kibibyte: "data.Kibibyte",
+		Mebibyte: "data.Mebibyte",
+		Gibibyte: "data.Gibibyte",
+		Tebibyte: "data.Tebibyte",
+		Pebibyte: "data.Pebibyte",
+		Kilobyte: "data.Kilobyte",
+		Megabyte: "data.Megabyte",
+		Gigabyte: "data.Gigabyte",
+		Terabyte: "data.Terabyte",
+		Petabyte: "data.Petabyte",
 	}
 )
 
@@ -114,4 +238,6 @@ func init() {
 	sort.Slice(all, func(i, j int) bool {
 		return all[i].Size < all[j].Size
 	})
+
+	codec = units.MustCompile(all)
 }
@@ -21,10 +21,17 @@
 package data_test
 
 import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/mjpitz/units"
 	"github.com/mjpitz/units/data"
+	"github.com/mjpitz/units/internal/quantitytest"
+	"github.com/mjpitz/units/unitstest"
 )
 
 func TestSize(t *testing.T) {
@@ -40,12 +47,23 @@ func TestSize(t *testing.T) {
 	require.Equal(t, 1000.0, data.Megabyte.As(data.Kilobyte))
 	require.Equal(t, 1000.0, data.Kilobyte.As(data.Byte))
 
-	require.Equal(t, "", data.BinaryIEC.Format(0))
+	require.Equal(t, "0B", data.BinaryIEC.Format(0))
+	require.Equal(t, "0B", data.Size(0).String())
 	require.Equal(t, "1PiB", data.BinaryIEC.Format(data.Pebibyte))
 	require.Equal(t, "1TiB", data.BinaryIEC.Format(data.Tebibyte))
 	require.Equal(t, "1GiB", data.BinaryIEC.Format(data.Gibibyte))
 	require.Equal(t, "1MiB", data.BinaryIEC.Format(data.Mebibyte))
 	require.Equal(t, "1KiB", data.BinaryIEC.Format(data.Kibibyte))
+	require.Equal(t, "-5GiB", data.BinaryIEC.Format(-5*data.Gibibyte))
+	require.Equal(t, "1.5GiB", data.BinaryIEC.Format(data.Gibibyte+512*data.Mebibyte, units.Largest()))
+	require.Equal(t, "1.07GB", data.Decimal.Format(data.Gibibyte, units.Largest(), units.Precision(2)))
+	require.Equal(t, "1536MiB", data.BinaryIEC.Format(data.Gibibyte+512*data.Mebibyte, units.Fixed("MiB")))
+	require.Equal(t, "1GiB", data.BinaryIEC.Format(data.Gibibyte-data.Byte, units.MaxComponents(1)))
+	require.Equal(t, "8191PiB", data.BinaryIEC.Format(math.MaxInt64, units.MaxComponents(1)))
+	require.Equal(t, "1 gibibyte 512 mebibytes", data.BinaryIEC.Format(data.Gibibyte+512*data.Mebibyte, units.Verbose()))
+	require.Equal(t, "1,5 Go", data.Decimal.Format(1500*data.Megabyte, units.Largest(), units.French))
+	require.Equal(t, "1,5 GB", data.Decimal.Format(1500*data.Megabyte, units.Largest(), units.German))
+	require.Equal(t, "1 kilobyte 1 byte", data.BinaryMemory.Format(data.Kibibyte+data.Byte, units.Verbose()))
 
 	require.Equal(t, "1PB", data.Petabyte.String())
 	require.Equal(t, "1TB", data.Terabyte.String())
@@ -53,6 +71,7 @@ func TestSize(t *testing.T) {
 	require.Equal(t, "1MB", data.Megabyte.String())
 	require.Equal(t, "1kB", data.Kilobyte.String())
 	require.Equal(t, "1B", data.Byte.String())
+	require.Equal(t, "-1GB", (-data.Gigabyte).String())
 
 	basic := 100 * data.Gibibyte
 
@@ -66,10 +85,40 @@ func TestSize(t *testing.T) {
 		{"+1GiB", false, data.Gibibyte},
 		{"10GiB", false, 10 * data.Gibibyte},
 		{"1GiB1MiB1KiB", false, data.Gibibyte + data.Mebibyte + data.Kibibyte},
+		{"9.007199254740993PB", false, 9007199254740993 * data.Byte},
+		{"0.1kB", false, 100 * data.Byte},
+		{"1.5e3MB", false, 1500 * data.Megabyte},
+		{"1_000_000B", false, data.Megabyte},
+		{"1,024KiB", false, data.Mebibyte},
+		{".5GiB", false, 512 * data.Mebibyte},
+		{"5 gibibytes", false, 5 * data.Gibibyte},
+		{"1 gigabyte 1 byte", false, data.Gigabyte + data.Byte},
+		{"1.2.3GB", true, 0},
+		{"10000PiB", true, 0},
 		{"100DNE", true, 0},
+		{"5", true, 0},
 		{"BAD", true, 0},
 	}
 
+	parsed, err := data.BinaryIEC.Parse("512mib", units.IgnoreCase())
+	require.NoError(t, err)
+	require.Equal(t, 512*data.Mebibyte, parsed)
+
+	parsed, err = data.Decimal.Parse("10gb", units.IgnoreCase())
+	require.NoError(t, err)
+	require.Equal(t, 10*data.Gigabyte, parsed)
+
+	parsed, err = data.Decimal.Parse("1,5 Go", units.French)
+	require.NoError(t, err)
+	require.Equal(t, 1500*data.Megabyte, parsed)
+
+	parsed, err = data.Decimal.ParseExpr("3 * (10GB - 1GB)")
+	require.NoError(t, err)
+	require.Equal(t, 27*data.Gigabyte, parsed)
+
+	_, err = data.BinaryIEC.ParseExpr("1GiB * 1GiB")
+	require.ErrorIs(t, err, units.ErrDimension)
+
 	for _, testCase := range testCases {
 		//set empty
 		err := (&basic).Set(testCase.set)
@@ -82,3 +131,176 @@ func TestSize(t *testing.T) {
 		require.Equal(t, testCase.expected, basic)
 	}
 }
+
+func TestUint64(t *testing.T) {
+	size, err := data.FromUint64(3 << 30)
+	require.NoError(t, err)
+	require.Equal(t, 3*data.Gibibyte, size)
+
+	_, err = data.FromUint64(math.MaxUint64)
+	require.ErrorIs(t, err, units.ErrOverflow)
+
+	raw, err := data.Pebibyte.Uint64()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1<<50), raw)
+
+	_, err = (-data.Byte).Uint64()
+	require.ErrorIs(t, err, units.ErrNegative)
+
+	// existing tables can be used to render unsigned counters directly
+	binary, err := units.ConvertUnit[uint64](data.BinaryIEC)
+	require.NoError(t, err)
+	require.Equal(t, "16383PiB1023TiB1023GiB1023MiB1023KiB1023B", binary.Format(math.MaxUint64))
+	require.Equal(t, "16383PiB", binary.Format(math.MaxUint64, units.MaxComponents(1)))
+	require.Equal(t, "16383PiB1023TiB", binary.Format(math.MaxUint64, units.MaxComponents(2)))
+}
+
+func TestRange(t *testing.T) {
+	var capacity units.Range[data.Size]
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.Var(&capacity, "capacity", "")
+
+	require.NoError(t, flags.Parse([]string{"-capacity", "10GiB-20GiB"}))
+	require.Equal(t, "10GiB..20GiB", capacity.Format(data.BinaryIEC))
+	require.True(t, capacity.Contains(15*data.Gibibyte))
+	require.False(t, capacity.Contains(20*data.Gibibyte+data.Byte))
+	require.Equal(t, 10*data.Gibibyte, capacity.Clamp(data.Gigabyte))
+
+	require.NoError(t, capacity.Set("<=5MB"))
+	require.Equal(t, "<=5MB", capacity.String())
+
+	require.NoError(t, capacity.Set("1GB..4GB"))
+	limit, err := units.ParseRange(data.BinaryIEC, ">2GiB")
+	require.NoError(t, err)
+
+	overlap, ok := capacity.Intersect(limit)
+	require.True(t, ok)
+	require.Equal(t, ">2GB147MB483kB648B <=4GB", overlap.String())
+	require.Equal(t, ">2.15GB <=4.00GB", overlap.Format(data.Decimal, units.Largest(), units.Precision(2)))
+
+	err = capacity.Set("20GiB-10GiB")
+	require.ErrorIs(t, err, units.ErrEmptyRange)
+}
+
+func TestRelative(t *testing.T) {
+	var limit units.Relative[data.Size]
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.Var(&limit, "memory-limit", "")
+
+	require.NoError(t, flags.Parse([]string{"-memory-limit", "80%"}))
+	require.Equal(t, "80%", limit.String())
+	require.Equal(t, 800*data.Megabyte, limit.Resolve(data.Gigabyte))
+
+	require.NoError(t, limit.Set("total-2GiB"))
+	require.Equal(t, "total-2GiB", limit.Format(data.BinaryIEC))
+	require.Equal(t, 6*data.Gibibyte, limit.Resolve(8*data.Gibibyte))
+
+	require.NoError(t, limit.Set("4GB"))
+	require.Equal(t, "4GB", limit.String())
+	require.Equal(t, 4*data.Gigabyte, limit.Resolve(data.Terabyte))
+
+	require.Error(t, limit.Set("total-4DNE"))
+}
+
+func TestEncoding(t *testing.T) {
+	quantitytest.Encoding(t, 512*data.Megabyte, "512MB", "0.512GB")
+}
+
+func TestSQL(t *testing.T) {
+	quantitytest.SQL(t, 512*data.Megabyte, "512MB", "0.512GB")
+}
+
+func TestJSON(t *testing.T) {
+	type response struct {
+		Used  units.JSON[data.Size] `json:"used"`
+		Total units.JSON[data.Size] `json:"total"`
+		Free  units.JSON[data.Size] `json:"free"`
+		Quota units.JSON[data.Size] `json:"quota"`
+	}
+
+	newResponse := func() response {
+		return response{
+			Used:  units.JSON[data.Size]{Style: units.JSONSingle, Unit: data.BinaryIEC},
+			Total: units.JSON[data.Size]{Style: units.JSONSingle, Unit: data.Decimal},
+			Free:  units.JSON[data.Size]{Style: units.JSONObject, Unit: data.BinaryIEC},
+		}
+	}
+
+	expected := newResponse()
+	expected.Used.Value = 1536 * data.Mebibyte
+	expected.Total.Value = 2 * data.Terabyte
+	expected.Free.Value = 512 * data.Gibibyte
+	expected.Quota.Value = data.Terabyte
+
+	encoded, err := json.Marshal(expected)
+	require.NoError(t, err)
+	require.Equal(t, `{"used":"1.5GiB","total":"2TB","free":{"value":512,"unit":"GiB"},"quota":1000000000000}`, string(encoded))
+
+	decoded := newResponse()
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Equal(t, expected, decoded)
+
+	// without a unit, strings are parsed by data.Size
+	var quota units.JSON[data.Size]
+	require.NoError(t, json.Unmarshal([]byte(`"1TB"`), &quota))
+	require.Equal(t, data.Terabyte, quota.Value)
+}
+
+func TestFormatter(t *testing.T) {
+	value := 1500 * data.Megabyte
+
+	testCases := []struct {
+		format   string
+		expected string
+	}{
+		{"%v", "1GB500MB"},
+		{"%.2v", "1.50GB"},
+		{"%+v", "1 gigabyte 500 megabytes"},
+		{"%#v", "data.Megabyte*1500"},
+		{"%d", "1500000000"},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, fmt.Sprintf(testCase.format, value), testCase.format)
+	}
+
+	require.Equal(t, "data.Gibibyte*3", fmt.Sprintf("%#v", 3*data.Gibibyte))
+
+	var scanned data.Size
+	_, err := fmt.Sscanf("limit=1.5GB", "limit=%v", &scanned)
+	require.NoError(t, err)
+	require.Equal(t, value, scanned)
+}
+
+func TestBig(t *testing.T) {
+	size, err := data.BigDecimal.Parse("1.5ZB")
+	require.NoError(t, err)
+	require.Equal(t, "1ZB500EB", data.BigDecimal.Format(size))
+	require.Equal(t, "1.27 zebibytes", data.BigBinaryIEC.Format(size, units.Largest(), units.Precision(2), units.Verbose()))
+
+	_, err = units.FromBig[data.Size](size)
+	require.ErrorIs(t, err, units.ErrOverflow)
+
+	size, err = data.BigBinaryIEC.Parse("2EiB")
+	require.NoError(t, err)
+
+	converted, err := units.FromBig[data.Size](size)
+	require.NoError(t, err)
+	require.Equal(t, 2048*data.Pebibyte, converted)
+
+	require.NoError(t, data.BigDecimal.Validate())
+	require.NoError(t, data.BigBinaryIEC.Validate())
+
+	// sizes are copied, so modifying them leaves the units untouched
+	exabyte := data.Exabyte()
+	exabyte.Mul(exabyte, exabyte)
+	require.Equal(t, 0, data.Exabyte().Cmp(data.BigDecimal[6].Size))
+	require.Equal(t, "1EB", data.BigDecimal.Format(data.Exabyte()))
+}
+
+func TestUnits(t *testing.T) {
+	unitstest.Validate(t, data.Decimal, data.BinaryIEC, data.BinaryMemory)
+	unitstest.RoundTrip(t, data.Decimal, data.BinaryIEC, data.BinaryMemory)
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JSONStyle determines how JSON encodes a value.
+type JSONStyle int
+
+const (
+	// JSONNumber encodes the value as a number in its base unit (for example, 1610612736). This is the default.
+	JSONNumber JSONStyle = iota + 1
+	// JSONCompound encodes the value as a string broken down into its components (for example, "1GiB512MiB").
+	JSONCompound
+	// JSONSingle encodes the value as a string holding a decimal of the largest symbol it fills (for example,
+	// "1.5GiB").
+	JSONSingle
+	// JSONObject encodes the value as an object holding a decimal of the largest symbol it fills along with the label
+	// of that symbol (for example, {"value":1.5,"unit":"GiB"}).
+	JSONObject
+)
+
+// JSON wraps a value so that the Style and Unit used to encode it can be chosen per field (for example, encoding one
+// field as "1.5GiB" using data.BinaryIEC and another as 1500000000 for machine consumers). Decoding accepts any of the
+// styles regardless of the configured Style, leaving the Style and Unit of the field unchanged, so defaults can be set
+// before decoding. Strings and objects are parsed using the Unit, rounding to the nearest base unit, or the Set method
+// of T when no Unit is configured.
+type JSON[T Number] struct {
+	Value T
+	Style JSONStyle
+	Unit  Unit[T]
+}
+
+// jsonObject holds the object form of a JSON value.
+type jsonObject struct {
+	Value json.Number `json:"value"`
+	Unit  string      `json:"unit"`
+}
+
+// MarshalJSON implements json.Marshaler. ErrEmptyUnit is returned when a Style other than JSONNumber is used without a
+// Unit.
+func (j JSON[T]) MarshalJSON() ([]byte, error) {
+	switch {
+	case j.Style == 0 || j.Style == JSONNumber:
+		return json.Marshal(integer(j.Value))
+	case len(j.Unit) == 0:
+		return nil, ErrEmptyUnit
+	}
+
+	switch j.Style {
+	case JSONCompound:
+		return json.Marshal(j.Unit.Format(j.Value))
+	case JSONSingle:
+		return json.Marshal(j.Unit.Format(j.Value, Largest()))
+	case JSONObject:
+		neg, mag := magnitude(j.Value)
+
+		i := j.Unit.largest(mag)
+		size := uint64(j.Unit[i].Size)
+
+		value := float64(mag/size) + float64(mag%size)/float64(size)
+		if neg {
+			value = -value
+		}
+
+		return json.Marshal(jsonObject{json.Number(strconv.FormatFloat(value, 'f', -1, 64)), j.Unit[i].Label[0]})
+	}
+
+	return nil, fmt.Errorf("unknown JSON style %d", j.Style)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting any of the styles.
+func (j *JSON[T]) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+
+	var text string
+	switch {
+	case string(data) == "null":
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	case len(data) > 0 && data[0] == '{':
+		var object jsonObject
+		if err := json.Unmarshal(data, &object); err != nil {
+			return err
+		}
+
+		text = object.Value.String() + object.Unit
+	default:
+		v, err := parseInteger[T](string(data))
+		if err != nil {
+			return err
+		}
+
+		j.Value = v
+		return nil
+	}
+
+	parse := set[T]
+	if len(j.Unit) > 0 {
+		parse = func(val string) (T, error) {
+			return j.Unit.Parse(val, BareIntegers())
+		}
+	}
+
+	v, err := parse(text)
+	if err != nil {
+		return err
+	}
+
+	j.Value = v
+	return nil
+}
+
+// UnmarshalJSON decodes a value of a quantity type, allowing the type to implement json.Unmarshaler alongside
+// encoding.TextMarshaler. Strings are parsed using the Codec (for example, "512MiB"), while integers, either bare or
+// quoted (see BareIntegers), are read as the value in its base unit for compatibility with data stored as numbers. As
+// is conventional, null leaves the value unchanged.
+func UnmarshalJSON[T Number](data []byte, value *T, codec *Codec[T]) error {
+	data = bytes.TrimSpace(data)
+
+	switch {
+	case string(data) == "null":
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+
+		v, err := codec.Parse(str, BareIntegers())
+		if err != nil {
+			return err
+		}
+
+		*value = v
+		return nil
+	}
+
+	v, err := parseInteger[T](string(data))
+	if err != nil {
+		return err
+	}
+
+	*value = v
+	return nil
+}
+
+// UnmarshalYAML decodes a value of a quantity type using the obsolete form of yaml.Unmarshaler, which gopkg.in/yaml.v2
+// and gopkg.in/yaml.v3 both support, allowing the type to implement it without depending on either. Strings are parsed
+// using the Codec (for example, "512MiB"), while integers, either bare or quoted, are read as the value in its base
+// unit. Floats (for example, 1.5) are rejected with ErrMissingSymbol since fractions need a symbol to be read exactly.
+// As with UnmarshalJSON, null leaves the value unchanged.
+//
+// The obsolete form doesn't provide the position of the value, so errors don't report the line and column where it
+// appears in the document. Use unitsyaml.Quantity for fields that should.
+func UnmarshalYAML[T Number](unmarshal func(interface{}) error, value *T, codec *Codec[T]) error {
+	var raw interface{}
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	var v T
+	var err error
+
+	switch raw := raw.(type) {
+	case nil:
+		return nil
+	case string:
+		v, err = codec.Parse(raw, BareIntegers())
+	case int:
+		v, err = Convert[T](raw)
+	case int64:
+		v, err = Convert[T](raw)
+	case uint64:
+		v, err = Convert[T](raw)
+	case float64:
+		return fmt.Errorf("cannot unmarshal %v into %T: %w", raw, *value, ErrMissingSymbol)
+	default:
+		return fmt.Errorf("cannot unmarshal %T into %T", raw, *value)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*value = v
+	return nil
+}
+
+// parseInteger parses the provided value in its base unit (for example, "-1024").
+func parseInteger[T Number](val string) (T, error) {
+	digits := strings.TrimPrefix(val, "+")
+	neg := strings.HasPrefix(digits, "-")
+
+	mag, err := strconv.ParseUint(strings.TrimPrefix(digits, "-"), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if signed, _ := limits[T](); neg && !signed {
+		return 0, ErrNegative
+	}
+
+	return checked[T](neg, mag)
+}
+
+// isInteger reports whether the provided value holds only an optionally signed integer (for example, "-1024").
+func isInteger(val string) bool {
+	if val != "" && (val[0] == '+' || val[0] == '-') {
+		val = val[1:]
+	}
+
+	return val != "" && strings.Trim(val, "0123456789") == ""
+}
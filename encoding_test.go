@@ -0,0 +1,173 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mjpitz/units"
+)
+
+func TestUnmarshalJSON(t *testing.T) {
+	codec := units.MustCompile(binary)
+
+	testCases := []struct {
+		data     string
+		expected int64
+	}{
+		{`"1GiB512MiB"`, 1<<30 + 512<<20},
+		{`"1 gibibyte"`, 1 << 30},
+		{`"1KiB"`, 1 << 10},
+		{`""`, 0},
+		{`1024`, 1 << 10},
+		{` -1024 `, -1 << 10},
+		{`0`, 0},
+	}
+
+	for _, testCase := range testCases {
+		var value int64
+		require.NoError(t, units.UnmarshalJSON([]byte(testCase.data), &value, codec), testCase.data)
+		require.Equal(t, testCase.expected, value, testCase.data)
+	}
+
+	value := int64(5)
+	require.NoError(t, units.UnmarshalJSON([]byte("null"), &value, codec))
+	require.Equal(t, int64(5), value)
+
+	for _, data := range []string{`1.5`, `1e3`, `"1DNE"`, `"1GiB`, `true`, ``, `99999999999999999999`} {
+		require.Error(t, units.UnmarshalJSON([]byte(data), &value, codec), data)
+		require.Equal(t, int64(5), value, data)
+	}
+
+	counters, err := units.ConvertUnit[uint64](binary)
+	require.NoError(t, err)
+
+	var unsigned uint64
+	err = units.UnmarshalJSON([]byte(strconv.FormatUint(1<<64-1, 10)), &unsigned, units.MustCompile(counters))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1<<64-1), unsigned)
+
+	err = units.UnmarshalJSON([]byte(`-1`), &unsigned, units.MustCompile(counters))
+	require.ErrorIs(t, err, units.ErrNegative)
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	codec := units.MustCompile(binary)
+
+	// decode mimics the unmarshal func provided by the yaml package for the scalar it decoded
+	decode := func(raw interface{}) func(interface{}) error {
+		return func(v interface{}) error {
+			*(v.(*interface{})) = raw
+			return nil
+		}
+	}
+
+	testCases := []struct {
+		raw      interface{}
+		expected int64
+	}{
+		{"1GiB512MiB", 1<<30 + 512<<20},
+		{"1 gibibyte", 1 << 30},
+		{"", 0},
+		{1024, 1 << 10},
+		{int64(-1024), -1 << 10},
+		{uint64(1 << 10), 1 << 10},
+		{"1024", 1 << 10},
+	}
+
+	for _, testCase := range testCases {
+		var value int64
+		require.NoError(t, units.UnmarshalYAML(decode(testCase.raw), &value, codec), testCase.raw)
+		require.Equal(t, testCase.expected, value, testCase.raw)
+	}
+
+	value := int64(5)
+	require.NoError(t, units.UnmarshalYAML(decode(nil), &value, codec))
+	require.Equal(t, int64(5), value)
+
+	for _, raw := range []interface{}{"1DNE", 1.5, true, []interface{}{"1GiB"}, map[string]interface{}{}, uint64(math.MaxUint64)} {
+		require.Error(t, units.UnmarshalYAML(decode(raw), &value, codec), raw)
+		require.Equal(t, int64(5), value)
+	}
+
+	require.ErrorIs(t, units.UnmarshalYAML(decode(uint64(math.MaxUint64)), &value, codec), units.ErrOverflow)
+	require.EqualError(t, units.UnmarshalYAML(decode(1.5), &value, codec), "cannot unmarshal 1.5 into int64: missing symbol")
+	require.ErrorIs(t, units.UnmarshalYAML(decode(-1), new(uint8), units.MustCompile(units.Unit[uint8]{{1, []string{"B"}, nil}})), units.ErrNegative)
+
+	failure := errors.New("failure")
+	require.ErrorIs(t, units.UnmarshalYAML(func(interface{}) error { return failure }, &value, codec), failure)
+}
+
+func TestJSON(t *testing.T) {
+	value := int64(1<<30 + 512<<20)
+
+	testCases := []struct {
+		style    units.JSONStyle
+		value    int64
+		expected string
+	}{
+		{0, value, `1610612736`},
+		{units.JSONNumber, -value, `-1610612736`},
+		{units.JSONCompound, value, `"1GiB512MiB"`},
+		{units.JSONSingle, value, `"1.5GiB"`},
+		{units.JSONSingle, 0, `"0B"`},
+		{units.JSONObject, value, `{"value":1.5,"unit":"GiB"}`},
+		{units.JSONObject, -value, `{"value":-1.5,"unit":"GiB"}`},
+		{units.JSONObject, 1<<30 + 1, `{"value":1.0000000009313226,"unit":"GiB"}`},
+		{units.JSONObject, 0, `{"value":0,"unit":"B"}`},
+	}
+
+	for _, testCase := range testCases {
+		encoded, err := json.Marshal(units.JSON[int64]{testCase.value, testCase.style, binary})
+		require.NoError(t, err)
+		require.Equal(t, testCase.expected, string(encoded))
+
+		// values are decoded using whichever style they were encoded with
+		decoded := units.JSON[int64]{Style: units.JSONNumber, Unit: binary}
+		require.NoError(t, json.Unmarshal(encoded, &decoded), testCase.expected)
+		require.Equal(t, testCase.value, decoded.Value, testCase.expected)
+		require.Equal(t, units.JSONNumber, decoded.Style)
+	}
+
+	_, err := json.Marshal(units.JSON[int64]{value, units.JSONSingle, nil})
+	require.ErrorIs(t, err, units.ErrEmptyUnit)
+
+	_, err = json.Marshal(units.JSON[int64]{value, units.JSONStyle(42), binary})
+	require.Error(t, err)
+
+	decoded := units.JSON[int64]{Unit: binary}
+	for _, data := range []string{`"1DNE"`, `{"value":true,"unit":"GiB"}`, `{"value":1,"unit":"DNE"}`, `1.5`} {
+		require.Error(t, json.Unmarshal([]byte(data), &decoded), data)
+	}
+
+	// without a unit, strings can only be decoded when the type implements Set
+	require.Error(t, json.Unmarshal([]byte(`"1GiB"`), &units.JSON[int64]{}))
+
+	unitless := units.JSON[int64]{}
+	require.NoError(t, json.Unmarshal([]byte(`1024`), &unitless))
+	require.Equal(t, int64(1024), unitless.Value)
+}
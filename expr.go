@@ -0,0 +1,296 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// ErrDimension notifies the caller that an expression combines values in a way that does not produce a quantity of
+	// the unit (for example, "1GiB * 1GiB" or "1GiB + 1").
+	ErrDimension = fmt.Errorf("operands have incompatible dimensions")
+
+	// ErrDivideByZero notifies the caller that an expression divides by zero.
+	ErrDivideByZero = fmt.Errorf("division by zero")
+)
+
+// ParseExpr evaluates the provided arithmetic expression (for example, "2*1GiB + 512MiB" or "3 * (10GB - 1GB)").
+// Operands are either quantities written using the same syntax as Parse or dimensionless scalars, and may be combined
+// using +, -, *, / and parentheses. Quantities may be added to or subtracted from other quantities, multiplied or
+// divided by scalars, and divided by other quantities to produce a scalar. Any other combination results in an
+// ErrDimension. Expressions are evaluated exactly, and any fraction of the base unit that remains is resolved using the
+// configured Rounding mode. When the expression cannot be evaluated, a *ParseError is returned describing where in the
+// input the problem occurred. Like Parse, each call indexes the Unit, which Codec.ParseExpr avoids.
+func (u Unit[T]) ParseExpr(val string, opts ...Option) (T, error) {
+	options := apply(opts)
+
+	idx, err := newIndex(u, options)
+	if err != nil {
+		return 0, err
+	}
+
+	return parseExpr(u, idx, val, options)
+}
+
+// expression evaluates an arithmetic expression over the quantities of a Unit using recursive descent.
+type expression[T Number] struct {
+	unit   Unit[T]
+	idx    *index
+	locale *Locale
+	input  string
+	i      int
+}
+
+// operand is the result of evaluating part of an expression spanning input[start:end]. Quantities are measured in the
+// base unit while scalars are dimensionless.
+type operand struct {
+	value      *big.Rat
+	quantity   bool
+	start, end int
+}
+
+func parseExpr[T Number](u Unit[T], idx *index, val string, options Options) (T, error) {
+	e := &expression[T]{unit: u, idx: idx, locale: options.Locale, input: val}
+	if e.skip(); e.i == len(val) {
+		return 0, nil
+	}
+
+	result, err := e.sum()
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case e.i < len(val):
+		return 0, &ParseError{val, e.i, nextToken(val[e.i:]), ErrValueDoesNotMatchPattern}
+	case !result.quantity && result.value.Sign() != 0:
+		return 0, &ParseError{val, result.end, "", ErrMissingSymbol}
+	}
+
+	return resolve[T](result, val, options)
+}
+
+// sum evaluates a sequence of products separated by + or -.
+func (e *expression[T]) sum() (operand, error) {
+	left, err := e.product()
+	for err == nil && e.i < len(e.input) && isSign(e.input[e.i]) {
+		op := e.input[e.i]
+		e.i++
+		e.skip()
+
+		var right operand
+		if right, err = e.product(); err != nil {
+			break
+		}
+
+		if left.quantity != right.quantity {
+			return left, e.fail(left, right, ErrDimension)
+		}
+
+		if op == '+' {
+			left.value.Add(left.value, right.value)
+		} else {
+			left.value.Sub(left.value, right.value)
+		}
+
+		left.end = right.end
+	}
+
+	return left, err
+}
+
+// product evaluates a sequence of factors separated by * or /.
+func (e *expression[T]) product() (operand, error) {
+	left, err := e.factor()
+	for err == nil && e.i < len(e.input) && (e.input[e.i] == '*' || e.input[e.i] == '/') {
+		op := e.input[e.i]
+		e.i++
+		e.skip()
+
+		var right operand
+		if right, err = e.factor(); err != nil {
+			break
+		}
+
+		switch {
+		case op == '*' && left.quantity && right.quantity:
+			return left, e.fail(left, right, ErrDimension)
+		case op == '*':
+			left.value.Mul(left.value, right.value)
+			left.quantity = left.quantity || right.quantity
+		case !left.quantity && right.quantity:
+			return left, e.fail(left, right, ErrDimension)
+		case right.value.Sign() == 0:
+			return left, e.fail(right, right, ErrDivideByZero)
+		default:
+			// dividing one quantity by another produces a scalar
+			left.value.Quo(left.value, right.value)
+			left.quantity = left.quantity && !right.quantity
+		}
+
+		left.end = right.end
+	}
+
+	return left, err
+}
+
+// factor evaluates a signed factor, a parenthesized expression, or an operand.
+func (e *expression[T]) factor() (operand, error) {
+	start := e.i
+	switch {
+	case e.i == len(e.input):
+		return operand{}, &ParseError{e.input, e.i, "", ErrValueDoesNotMatchPattern}
+	case isSign(e.input[e.i]):
+		op := e.input[e.i]
+		e.i++
+		e.skip()
+
+		value, err := e.factor()
+		if op == '-' && err == nil {
+			value.value.Neg(value.value)
+		}
+
+		value.start = start
+		return value, err
+	case e.input[e.i] == '(':
+		e.i++
+		e.skip()
+
+		value, err := e.sum()
+		if err != nil {
+			return value, err
+		}
+
+		if e.i == len(e.input) || e.input[e.i] != ')' {
+			return value, &ParseError{e.input, e.i, nextToken(e.input[e.i:]), ErrValueDoesNotMatchPattern}
+		}
+
+		e.i++
+		value.start, value.end = start, e.i
+		e.skip()
+		return value, nil
+	}
+
+	return e.operand()
+}
+
+// operand reads a scalar, or a quantity made up of one or more components (for example, "1GiB 512MiB").
+func (e *expression[T]) operand() (operand, error) {
+	result := operand{value: new(big.Rat), start: e.i}
+	for {
+		measure := e.i
+		end := e.locale.scan(e.input, measure)
+		if end == measure {
+			return result, &ParseError{e.input, measure, nextToken(e.input[measure:]), ErrValueDoesNotMatchPattern}
+		}
+
+		digits, scale, err := splitMeasure(e.locale.normalize(e.input[measure:end]))
+		if err != nil {
+			return result, &ParseError{e.input, measure, e.input[measure:end], err}
+		}
+
+		c := component{digits: digits, scale: scale}
+
+		i := skipSpace(e.input, end)
+		label, pos, ok := e.idx.match(e.input, i, true)
+		if !ok {
+			boundary := i == len(e.input) || isSign(e.input[i]) || isOperator(e.input[i])
+			switch {
+			case boundary && !result.quantity:
+				e.i = i
+				e.skip()
+				return operand{c.value(big.NewInt(1)), false, result.start, end}, nil
+			case boundary || isMeasure(e.input[i]):
+				return result, &ParseError{e.input, end, "", ErrMissingSymbol}
+			}
+
+			return result, &ParseError{e.input, i, nextToken(e.input[i:]), ErrUnrecognizedSymbol}
+		}
+
+		result.value.Add(result.value, c.value(ToBig(e.unit[pos].Size)))
+		result.quantity = true
+		result.end = i + len(label)
+
+		e.i = result.end
+		if e.skip(); e.i == len(e.input) || !isMeasure(e.input[e.i]) {
+			return result, nil
+		}
+	}
+}
+
+// skip advances past any whitespace.
+func (e *expression[T]) skip() {
+	for e.i < len(e.input) {
+		r, size := utf8.DecodeRuneInString(e.input[e.i:])
+		if !unicode.IsSpace(r) {
+			return
+		}
+
+		e.i += size
+	}
+}
+
+// fail reports a problem combining the provided operands, citing the text spanning both.
+func (e *expression[T]) fail(left, right operand, err error) error {
+	return &ParseError{e.input, left.start, strings.TrimSpace(e.input[left.start:right.end]), err}
+}
+
+// resolve converts the result of an expression to T, resolving any fraction of the base unit using the configured
+// Rounding mode.
+func resolve[T Number](result operand, input string, options Options) (T, error) {
+	fail := func(err error) error {
+		return &ParseError{input, result.start, input[result.start:result.end], err}
+	}
+
+	neg := result.value.Sign() < 0
+	mag := new(big.Rat).Abs(result.value)
+
+	whole, rem := new(big.Int).QuoRem(mag.Num(), mag.Denom(), new(big.Int))
+	if rem.Sign() != 0 {
+		if options.Strict {
+			return 0, fail(ErrInexact)
+		}
+
+		if roundUp(neg, whole.Bit(0) == 1, rem, mag.Denom(), options.Rounding) {
+			whole.Add(whole, big.NewInt(1))
+		}
+	}
+
+	if signed, _ := limits[T](); neg && !signed && whole.Sign() != 0 {
+		return 0, fail(ErrNegative)
+	}
+
+	if !whole.IsUint64() {
+		return 0, fail(ErrOverflow)
+	}
+
+	value, err := checked[T](neg, whole.Uint64())
+	if err != nil {
+		return 0, fail(err)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mjpitz/units"
+)
+
+func TestParseExpr(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected int64
+	}{
+		{"", 0},
+		{"0", 0},
+		{"1GiB", 1 << 30},
+		{"1GiB512MiB", 1<<30 + 512<<20},
+		{"1 GiB 512 MiB", 1<<30 + 512<<20},
+		{"2*1GiB + 512MiB", 2<<30 + 512<<20},
+		{"3 * (10KiB - 1KiB)", 27 << 10},
+		{"(1GiB)*3/4", 768 << 20},
+		{"1GiB / 3", 357913941},
+		{"1GiB - 2GiB", -1 << 30},
+		{"-(1KiB + 1B)", -1025},
+		{"--1KiB", 1 << 10},
+		{"1.5 * 2KiB", 3 << 10},
+		{"1e3 * 1B", 1000},
+		{"4GiB / 1GiB * 1MiB", 4 << 20},
+		{"2 * 3 * 1B", 6},
+		{"1KiB-1B", 1023},
+		{" ( 1KiB ) ", 1 << 10},
+		{"10 kibibytes / 2", 5 << 10},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := binary.ParseExpr(testCase.input)
+		require.NoError(t, err, testCase.input)
+		require.Equal(t, testCase.expected, actual, testCase.input)
+	}
+
+	rounded, err := binary.ParseExpr("1KiB / 3", units.Rounding(units.RoundCeil))
+	require.NoError(t, err)
+	require.Equal(t, int64(342), rounded)
+
+	_, err = binary.ParseExpr("1KiB / 3", units.Strict())
+	require.ErrorIs(t, err, units.ErrInexact)
+
+	folded, err := binary.ParseExpr("2 * 1gib", units.IgnoreCase())
+	require.NoError(t, err)
+	require.Equal(t, int64(2<<30), folded)
+
+	codec := units.MustCompile(binary)
+	parsed, err := codec.ParseExpr("2*1GiB + 512MiB")
+	require.NoError(t, err)
+	require.Equal(t, int64(2<<30+512<<20), parsed)
+
+	german, err := binary.ParseExpr("1,5 * 2 KiB", units.German)
+	require.NoError(t, err)
+	require.Equal(t, int64(3<<10), german)
+}
+
+func TestParseExpr_Errors(t *testing.T) {
+	testCases := []struct {
+		input  string
+		offset int
+		token  string
+		cause  error
+	}{
+		{"1GiB * 1GiB", 0, "1GiB * 1GiB", units.ErrDimension},
+		{"2 + (1GiB * 1KiB)", 5, "1GiB * 1KiB", units.ErrDimension},
+		{"1GiB + 1", 0, "1GiB + 1", units.ErrDimension},
+		{"2 / 1GiB", 0, "2 / 1GiB", units.ErrDimension},
+		{"1GiB / (1 - 1)", 7, "(1 - 1)", units.ErrDivideByZero},
+		{"2 * 3", 5, "", units.ErrMissingSymbol},
+		{"1GiB 5", 6, "", units.ErrMissingSymbol},
+		{"2 * 1DNE", 5, "DNE", units.ErrUnrecognizedSymbol},
+		{"1DNE*2", 1, "DNE", units.ErrUnrecognizedSymbol},
+		{"2*(1GiB+1XiB)/2", 9, "XiB", units.ErrUnrecognizedSymbol},
+		{"(1DNE)", 2, "DNE", units.ErrUnrecognizedSymbol},
+		{"1GiB */ 2", 6, "/", units.ErrValueDoesNotMatchPattern},
+		{"(1GiB", 5, "", units.ErrValueDoesNotMatchPattern},
+		{"1GiB)", 4, ")", units.ErrValueDoesNotMatchPattern},
+		{"1GiB *", 6, "", units.ErrValueDoesNotMatchPattern},
+		{"* 1GiB", 0, "*", units.ErrValueDoesNotMatchPattern},
+		{"1.2.3GiB", 0, "1.2.3", units.ErrInvalidNumber},
+		{"8589934592GiB", 0, "8589934592GiB", units.ErrOverflow},
+		{"(4GiB * 1024 * 1024 * 1024) * 2", 0, "(4GiB * 1024 * 1024 * 1024) * 2", units.ErrOverflow},
+	}
+
+	for _, testCase := range testCases {
+		_, err := binary.ParseExpr(testCase.input)
+		require.ErrorIs(t, err, testCase.cause, testCase.input)
+
+		var parseErr *units.ParseError
+		require.ErrorAs(t, err, &parseErr, testCase.input)
+		require.Equal(t, testCase.input, parseErr.Input)
+		require.Equal(t, testCase.offset, parseErr.Offset, testCase.input)
+		require.Equal(t, testCase.token, parseErr.Token, testCase.input)
+	}
+
+	// large intermediate results are fine as long as the result fits
+	parsed, err := binary.ParseExpr("4GiB * 1024 * 1024 * 1024 * 8 / 16")
+	require.NoError(t, err)
+	require.Equal(t, int64(1<<61), parsed)
+
+	unsigned, err := units.ConvertUnit[uint32](units.Unit[int64]{{1, []string{"B"}, nil}})
+	require.NoError(t, err)
+
+	_, err = unsigned.ParseExpr("1B - 2B")
+	require.ErrorIs(t, err, units.ErrNegative)
+}
@@ -0,0 +1,353 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Float defines a constraint for the floating point values supported by FloatUnit.
+type Float interface {
+	constraints.Float
+}
+
+// FloatUnit is like Unit, but works with floating point values. This suits quantities that are inherently continuous
+// (for example, sensor readings) where truncating to a whole number of the base unit would lose resolution. FloatUnit
+// shares its Symbol definitions with Unit, though symbols may also be fractions of the base unit. Like Unit, symbols
+// must be sorted in ascending order by size.
+type FloatUnit[F Float] []Symbol[F]
+
+// NewFloatUnit converts the symbols of the provided Unit to a FloatUnit sharing the same base unit.
+func NewFloatUnit[F Float, T Number](u Unit[T]) FloatUnit[F] {
+	unit := make(FloatUnit[F], 0, len(u))
+	for _, symbol := range u {
+		unit = append(unit, Symbol[F]{F(symbol.Size), symbol.Label, symbol.Names})
+	}
+
+	return unit
+}
+
+func (u FloatUnit[F]) count() int {
+	return len(u)
+}
+
+func (u FloatUnit[F]) symbol(i int) (label, names []string) {
+	return u[i].Label, u[i].Names
+}
+
+func (u FloatUnit[F]) same(i, j int) bool {
+	return u[i].Size == u[j].Size
+}
+
+// Format converts the provided value to a human-readable string using the same options as Unit.Format. Fractional
+// values are broken down using their shortest decimal representation so that components are free of binary rounding
+// errors (for example, 2.3 kilograms renders as "2kg300g" rather than "2kg299.9999999999998g"). NaN and infinite values
+// are rendered using the smallest symbol.
+func (u FloatUnit[F]) Format(value F, opts ...Option) (str string) {
+	if len(u) == 0 {
+		return ""
+	}
+
+	options := apply(opts)
+
+	f := float64(value)
+	switch {
+	case f == 0:
+		return renderZero(u, options)
+	case math.IsNaN(f) || math.IsInf(f, 0):
+		return render(u, strconv.FormatFloat(f, 'g', -1, 64), 0, options)
+	case f < 0:
+		str = "-"
+	case options.ExplicitPlus:
+		str = "+"
+	}
+
+	mag := exact(F(math.Abs(f)))
+	switch options.Style {
+	case StyleLargest:
+		return str + u.single(mag, u.carry(mag, u.largest(mag), options), options)
+	case StyleFixed:
+		i := find(u, options.Symbol)
+		if i < 0 {
+			i = u.largest(mag)
+		}
+
+		return str + u.single(mag, i, options)
+	}
+
+	if options.MaxComponents > 0 {
+		mag = u.limit(mag, options.MaxComponents)
+	}
+
+	// verbose and spaced components are separated by a space (for example, "1 kilogram 300 grams")
+	first := len(str)
+	separate := func() {
+		if (options.Verbose || options.Locale != nil && options.Locale.Space) && len(str) > first {
+			str += " "
+		}
+	}
+
+	for i := len(u); mag.Sign() > 0 && i > 1; i-- {
+		size := exact(u[i-1].Size)
+		if mag.Cmp(size) >= 0 {
+			var quo *big.Int
+
+			separate()
+			quo, mag = quoRem(mag, size)
+			str += render(u, quo.String(), i-1, options)
+		}
+	}
+
+	if mag.Sign() > 0 {
+		separate()
+		str += u.single(mag, 0, options)
+	}
+
+	return str
+}
+
+// limit rounds the magnitude so that it can be represented using at most n components, rounding down when rounding up
+// would exceed the range of F. See Unit.limit.
+func (u FloatUnit[F]) limit(mag *big.Rat, n int) *big.Rat {
+	for {
+		components := 0
+		rem := new(big.Rat).Set(mag)
+		for i := len(u) - 1; rem.Sign() > 0 && i >= 0; i-- {
+			size := exact(u[i].Size)
+			if rem.Cmp(size) < 0 && i > 0 {
+				continue
+			}
+
+			components++
+			if components == n {
+				if i == 0 {
+					return mag
+				}
+
+				// only the remainder is rounded (half away from zero) since larger symbols need not be multiples of
+				// this one
+				quo, r := quoRem(rem, size)
+				if r.Cmp(new(big.Rat).Sub(size, r)) >= 0 {
+					quo.Add(quo, big.NewInt(1))
+				}
+
+				rounded := new(big.Rat).Mul(new(big.Rat).SetInt(quo), size)
+				rounded.Add(rounded, mag).Sub(rounded, rem)
+				if rounded.Cmp(maxFloat[F]()) > 0 {
+					rounded.Sub(mag, r)
+				}
+
+				if rounded.Cmp(mag) == 0 {
+					return mag
+				}
+
+				mag = rounded
+				break
+			}
+
+			_, rem = quoRem(rem, size)
+		}
+
+		if components < n {
+			return mag
+		}
+	}
+}
+
+// single renders the magnitude as a decimal number of the i-th symbol.
+func (u FloatUnit[F]) single(mag *big.Rat, i int, options Options) string {
+	return render(u, u.number(mag, i, options), i, options)
+}
+
+// number renders the magnitude as a decimal number of the i-th symbol without its label.
+func (u FloatUnit[F]) number(mag *big.Rat, i int, options Options) string {
+	value, _ := new(big.Rat).Quo(mag, exact(u[i].Size)).Float64()
+	return strconv.FormatFloat(value, options.Format, options.Precision, 64)
+}
+
+// carry moves up from the i-th symbol while the rounded magnitude reaches the size of the next symbol. See Unit.carry.
+func (u FloatUnit[F]) carry(mag *big.Rat, i int, options Options) int {
+	for ; i+1 < len(u); i++ {
+		if !carries(u.number(mag, i, options), exact(u[i].Size), exact(u[i+1].Size)) {
+			break
+		}
+	}
+
+	return i
+}
+
+// largest returns the index of the largest symbol the magnitude fills. When the magnitude is smaller than every symbol,
+// the smallest symbol is returned.
+func (u FloatUnit[F]) largest(mag *big.Rat) int {
+	for i := len(u) - 1; i > 0; i-- {
+		if mag.Cmp(exact(u[i].Size)) >= 0 {
+			return i
+		}
+	}
+
+	return 0
+}
+
+// Parse converts the provided string value to its floating point representation using the same syntax as Unit.Parse.
+// Since fractions of the base unit are preserved, the Rounding and Strict options do not apply. Instead, the exact
+// value is rounded to the nearest value F can represent. ErrOverflow is returned when the value exceeds the range of F.
+func (u FloatUnit[F]) Parse(val string, opts ...Option) (F, error) {
+	options := apply(opts)
+
+	idx, err := newIndex(u, options)
+	if err != nil {
+		return 0, err
+	}
+
+	s, err := newScanner(idx, val, options)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		total = new(big.Rat)
+		// last tracks the last component that was parsed so overflows can be reported
+		last component
+	)
+
+	for s.more() {
+		c, err := s.next()
+		if err != nil {
+			return 0, err
+		}
+
+		last = c
+
+		value := c.value(big.NewInt(1))
+		total.Add(total, value.Mul(value, exact(u[c.symbol].Size)))
+	}
+
+	if s.neg {
+		total.Neg(total)
+	}
+
+	value, ok := nearest[F](total)
+	if !ok {
+		return 0, s.fail(last, ErrOverflow)
+	}
+
+	return value, nil
+}
+
+// As returns the value as a multiple of the symbol with the provided label (for example, the number of kilograms in a
+// mass). ErrUnrecognizedSymbol is returned when no symbol uses the label.
+func (u FloatUnit[F]) As(value F, label string) (F, error) {
+	i := find(u, label)
+	if i < 0 {
+		return 0, ErrUnrecognizedSymbol
+	}
+
+	return value / u[i].Size, nil
+}
+
+// ToFloat converts the provided integer value to F. Values that exceed the precision of F are rounded to the nearest
+// value F can represent.
+func ToFloat[F Float, T Number](value T) F {
+	return F(value)
+}
+
+// FromFloat converts the provided floating point value to T, resolving any fraction of the base unit using the provided
+// RoundingMode (truncating when zero). Like FloatUnit.Format, the shortest decimal representation of a fractional value
+// is used so that values such as 0.1+0.2 are rounded as written rather than by their binary approximation. ErrOverflow
+// is returned when the rounded value cannot be represented by T (including infinities), ErrNegative when a negative
+// value is converted to an unsigned type, and ErrInvalidNumber when the value is NaN.
+func FromFloat[T Number, F Float](value F, mode RoundingMode) (T, error) {
+	f := float64(value)
+	switch {
+	case math.IsNaN(f):
+		return 0, ErrInvalidNumber
+	case math.IsInf(f, 0):
+		return 0, ErrOverflow
+	}
+
+	neg := f < 0
+	mag := exact(F(math.Abs(f)))
+
+	whole, rem := new(big.Int).QuoRem(mag.Num(), mag.Denom(), new(big.Int))
+	if rem.Sign() != 0 && roundUp(neg, whole.Bit(0) == 1, rem, mag.Denom(), mode) {
+		whole.Add(whole, big.NewInt(1))
+	}
+
+	if !whole.IsUint64() {
+		return 0, ErrOverflow
+	}
+
+	if signed, _ := limits[T](); neg && !signed && whole.Sign() != 0 {
+		return 0, ErrNegative
+	}
+
+	return checked[T](neg, whole.Uint64())
+}
+
+// exact returns a finite value as an exact fraction. Whole numbers are converted exactly, while fractions use their
+// shortest decimal representation so that binary rounding errors (such as those in 0.1+0.2) are not carried over.
+func exact[F Float](value F) *big.Rat {
+	f := float64(value)
+	if f == math.Trunc(f) {
+		return new(big.Rat).SetFloat64(f)
+	}
+
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, precision[F]()))
+	return r
+}
+
+// nearest rounds the fraction to the nearest value F can represent, reporting false when it exceeds the range of F.
+func nearest[F Float](r *big.Rat) (F, bool) {
+	if precision[F]() == 32 {
+		f, _ := r.Float32()
+		return F(f), !math.IsInf(float64(f), 0)
+	}
+
+	f, _ := r.Float64()
+	return F(f), !math.IsInf(f, 0)
+}
+
+// maxFloat returns the largest finite value F can represent.
+func maxFloat[F Float]() *big.Rat {
+	if precision[F]() == 32 {
+		return new(big.Rat).SetFloat64(math.MaxFloat32)
+	}
+
+	return new(big.Rat).SetFloat64(math.MaxFloat64)
+}
+
+// precision returns the size of F in bits.
+func precision[F Float]() int {
+	var zero F
+	return reflect.TypeOf(zero).Bits()
+}
+
+// quoRem returns the whole number of times size divides into mag along with the remainder.
+func quoRem(mag, size *big.Rat) (*big.Int, *big.Rat) {
+	r := new(big.Rat).Quo(mag, size)
+	quo := new(big.Int).Quo(r.Num(), r.Denom())
+	return quo, r.Sub(mag, r.Mul(new(big.Rat).SetInt(quo), size))
+}
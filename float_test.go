@@ -0,0 +1,179 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mjpitz/units"
+)
+
+var kilograms = units.FloatUnit[float64]{
+	{0.001, []string{"g"}, []string{"gram"}},
+	{1, []string{"kg"}, []string{"kilogram"}},
+	{1000, []string{"t"}, []string{"tonne"}},
+}
+
+func TestFloatUnit_Format(t *testing.T) {
+	testCases := []struct {
+		value    float64
+		opts     []units.Option
+		expected string
+	}{
+		{0, nil, "0g"},
+		{2.3, nil, "2kg300g"},
+		{-2.3, nil, "-2kg300g"},
+		{2.3, []units.Option{units.ExplicitPlus()}, "+2kg300g"},
+		{0.0005, nil, "0.5g"},
+		{1234.5678, nil, "1t234kg567.8g"},
+		{1234.5678, []units.Option{units.Largest()}, "1.2345678t"},
+		{1234.5678, []units.Option{units.Largest(), units.Precision(2)}, "1.23t"},
+		{999.9999, []units.Option{units.Largest(), units.Precision(2)}, "1.00t"},
+		{1234.5678, []units.Option{units.Fixed("g")}, "1234567.8g"},
+		{1234.5678, []units.Option{units.MaxComponents(2)}, "1t235kg"},
+		{1999.9999, []units.Option{units.MaxComponents(2)}, "2t"},
+		{1234.5678, []units.Option{units.Verbose()}, "1 tonne 234 kilograms 567.8 grams"},
+		{1, []units.Option{units.Verbose()}, "1 kilogram"},
+		{1.5, []units.Option{units.German}, "1 kg 500 g"},
+		{math.Inf(1), nil, "+Infg"},
+		{math.NaN(), nil, "NaNg"},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, kilograms.Format(testCase.value, testCase.opts...), testCase.expected)
+	}
+
+	require.Equal(t, "", units.FloatUnit[float64]{}.Format(1))
+
+	// rounding never leaves the range of the type
+	huge := units.FloatUnit[float32]{{1, []string{"B"}, nil}, {2e38, []string{"X"}, nil}}
+	require.Equal(t, "1X", huge.Format(math.MaxFloat32, units.MaxComponents(1)))
+}
+
+func TestFloatUnit_Parse(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected float64
+	}{
+		{"", 0},
+		{"2kg300g", 2.3},
+		{"-2kg 300g", -2.3},
+		{"0.5g", 0.0005},
+		{"1.2345678t", 1234.5678},
+		{"1 tonne 234 kilograms 567.8 grams", 1234.5678},
+		{"1.5e3g", 1.5},
+	}
+
+	for _, testCase := range testCases {
+		parsed, err := kilograms.Parse(testCase.input)
+		require.NoError(t, err, testCase.input)
+		require.Equal(t, testCase.expected, parsed, testCase.input)
+	}
+
+	for _, value := range []float64{0.1, 2.3, 1e-9, 1234.5678, 1e300, math.MaxFloat64} {
+		parsed, err := kilograms.Parse(kilograms.Format(value))
+		require.NoError(t, err)
+		require.Equal(t, value, parsed)
+	}
+
+	_, err := kilograms.Parse("1e400t")
+	require.ErrorIs(t, err, units.ErrOverflow)
+
+	_, err = units.FloatUnit[float32]{{1, []string{"B"}, nil}}.Parse("1e39B")
+	require.ErrorIs(t, err, units.ErrOverflow)
+
+	_, err = kilograms.Parse("1DNE")
+	require.ErrorIs(t, err, units.ErrUnrecognizedSymbol)
+
+	tonnes, err := kilograms.As(1500, "t")
+	require.NoError(t, err)
+	require.Equal(t, 1.5, tonnes)
+
+	_, err = kilograms.As(1500, "DNE")
+	require.ErrorIs(t, err, units.ErrUnrecognizedSymbol)
+}
+
+func TestNewFloatUnit(t *testing.T) {
+	unit := units.NewFloatUnit[float32](binary)
+	require.Len(t, unit, len(binary))
+	require.Equal(t, float32(1<<30), unit[3].Size)
+	require.Equal(t, binary[3].Label, unit[3].Label)
+
+	require.Equal(t, "1GiB512MiB", unit.Format(1<<30+512<<20))
+	require.Equal(t, "1KiB0.5B", unit.Format(1024.5))
+	require.Equal(t, "1.25KiB", unit.Format(1280, units.Largest()))
+
+	parsed, err := unit.Parse("1.5KiB 0.25B")
+	require.NoError(t, err)
+	require.Equal(t, float32(1536.25), parsed)
+}
+
+func TestFromFloat(t *testing.T) {
+	testCases := []struct {
+		value    float64
+		mode     units.RoundingMode
+		expected int64
+	}{
+		{2.5, 0, 2},
+		{-2.5, units.RoundTruncate, -2},
+		{2.5, units.RoundFloor, 2},
+		{-2.5, units.RoundFloor, -3},
+		{2.1, units.RoundCeil, 3},
+		{2.5, units.RoundHalfEven, 2},
+		{3.5, units.RoundHalfEven, 4},
+		{2.6, units.RoundHalfEven, 3},
+		{0.1 + 0.2, units.RoundHalfEven, 0},
+		{(0.1 + 0.2) * 10, units.RoundTruncate, 3},
+		{-9223372036854775808, 0, math.MinInt64},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := units.FromFloat[int64](testCase.value, testCase.mode)
+		require.NoError(t, err, "%v", testCase.value)
+		require.Equal(t, testCase.expected, actual, "%v", testCase.value)
+	}
+
+	_, err := units.FromFloat[int64](math.Inf(1), 0)
+	require.ErrorIs(t, err, units.ErrOverflow)
+
+	_, err = units.FromFloat[int64](9223372036854775808.0, 0)
+	require.ErrorIs(t, err, units.ErrOverflow)
+
+	_, err = units.FromFloat[int64](math.NaN(), 0)
+	require.ErrorIs(t, err, units.ErrInvalidNumber)
+
+	_, err = units.FromFloat[uint64](-1.5, 0)
+	require.ErrorIs(t, err, units.ErrNegative)
+
+	zero, err := units.FromFloat[uint64](-0.5, units.RoundTruncate)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), zero)
+
+	small, err := units.FromFloat[int8](float32(127.4), units.RoundHalfEven)
+	require.NoError(t, err)
+	require.Equal(t, int8(127), small)
+
+	require.Equal(t, 1024.0, units.ToFloat[float64](int64(1024)))
+	require.Equal(t, float32(1<<24), units.ToFloat[float32](int64(1<<24+1)))
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FormatValue renders a value of a quantity type for the fmt package, allowing the type to implement fmt.Formatter. The
+// following verbs are supported:
+//
+//	%v, %s   the value broken down into its components using the Unit (for example, "1GiB512MiB")
+//	%.Nv     the value as a decimal of the largest symbol it fills, using N decimal places (for example, "1.50GiB")
+//	%+v      the value using the long-form names of each symbol (for example, "1 gibibyte 512 mebibytes")
+//	%#v      a Go expression using the provided constants (for example, "data.Gibibyte*3")
+//	%q       the formatted value as a double-quoted string
+//	%d       the value in its base unit, as are the other integer verbs (%b, %o, %O, %x, %X)
+//
+// Width and the '-' flag pad the rendered text as they would for a string. Constants map the sizes of a quantity to the
+// qualified names of the Go constants that declare them. When no constant evenly divides the value, the Go expression
+// falls back to a conversion (for example, "data.Size(5)").
+func FormatValue[T Number](s fmt.State, verb rune, value T, u Unit[T], constants map[T]string) {
+	switch verb {
+	case 'd', 'b', 'o', 'O', 'x', 'X':
+		fmt.Fprintf(s, formatString(s, verb), integer(value))
+		return
+	case 'v', 's', 'q':
+	default:
+		fmt.Fprintf(s, "%%!%c(%T=%s)", verb, value, u.Format(value))
+		return
+	}
+
+	var opts []Option
+	if precision, ok := s.Precision(); ok {
+		opts = append(opts, Largest(), Precision(precision))
+	}
+
+	if s.Flag('+') {
+		opts = append(opts, Verbose())
+	}
+
+	var str string
+	switch {
+	case verb == 'v' && s.Flag('#'):
+		str = goSyntax(value, constants)
+	case verb == 'q':
+		str = strconv.Quote(u.Format(value, opts...))
+	default:
+		str = u.Format(value, opts...)
+	}
+
+	pad(s, str)
+}
+
+// goSyntax renders the value as a multiple of the largest constant that evenly divides it.
+func goSyntax[T Number](value T, constants map[T]string) string {
+	var size T
+	for candidate := range constants {
+		if candidate > size && value%candidate == 0 {
+			size = candidate
+		}
+	}
+
+	switch {
+	case size == 0 || value == 0:
+		return fmt.Sprintf("%T(%d)", value, integer(value))
+	case value == size:
+		return constants[size]
+	}
+
+	return fmt.Sprintf("%s*%d", constants[size], integer(value/size))
+}
+
+// integer converts the value to an int64 or uint64 (depending on the signedness of T) so that it can be rendered by the
+// fmt package without invoking the Format method of T.
+func integer[T Number](value T) interface{} {
+	if signed, _ := limits[T](); signed {
+		return int64(value)
+	}
+
+	return uint64(value)
+}
+
+// formatString reconstructs the format directive described by the fmt.State and verb.
+func formatString(s fmt.State, verb rune) string {
+	var sb strings.Builder
+	sb.WriteByte('%')
+	for _, flag := range "+-# 0" {
+		if s.Flag(int(flag)) {
+			sb.WriteRune(flag)
+		}
+	}
+
+	if width, ok := s.Width(); ok {
+		sb.WriteString(strconv.Itoa(width))
+	}
+
+	if precision, ok := s.Precision(); ok {
+		sb.WriteByte('.')
+		sb.WriteString(strconv.Itoa(precision))
+	}
+
+	sb.WriteRune(verb)
+	return sb.String()
+}
+
+// pad writes the text to the fmt.State, padding it with spaces to the configured width.
+func pad(s fmt.State, str string) {
+	width, ok := s.Width()
+	if n := utf8.RuneCountInString(str); ok && n < width {
+		padding := strings.Repeat(" ", width-n)
+		if s.Flag('-') {
+			str += padding
+		} else {
+			str = padding + str
+		}
+	}
+
+	_, _ = s.Write([]byte(str))
+}
+
+// ScanValue reads a value of a quantity type for the fmt package, allowing the type to implement fmt.Scanner. The %v and
+// %s verbs read a single space-delimited token and parse it using the Codec (for example, "1GiB512MiB"). The %d verb
+// reads the value in its base unit.
+func ScanValue[T Number](state fmt.ScanState, verb rune, codec *Codec[T]) (T, error) {
+	switch verb {
+	case 'v', 's', 'd':
+	default:
+		return 0, fmt.Errorf("bad verb '%%%c' for quantity", verb)
+	}
+
+	token, err := state.Token(true, func(r rune) bool {
+		return !unicode.IsSpace(r)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(token) == 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	if verb != 'd' {
+		return codec.Parse(string(token))
+	}
+
+	return parseInteger[T](string(token))
+}
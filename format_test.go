@@ -0,0 +1,157 @@
+package units_test
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mjpitz/units"
+)
+
+type bytes int64
+
+var (
+	bytesUnit = units.Unit[bytes]{
+		{1, []string{"B"}, []string{"byte"}},
+		{1 << 10, []string{"KiB"}, []string{"kibibyte"}},
+		{1 << 20, []string{"MiB"}, []string{"mebibyte"}},
+		{1 << 30, []string{"GiB"}, []string{"gibibyte"}},
+	}
+
+	bytesCodec = units.MustCompile(bytesUnit)
+
+	bytesConstants = map[bytes]string{
+		1:       "pkg.Byte",
+		1 << 10: "pkg.Kibibyte",
+		1 << 20: "pkg.Mebibyte",
+		1 << 30: "pkg.Gibibyte",
+	}
+)
+
+func (b bytes) Format(f fmt.State, verb rune) {
+	units.FormatValue(f, verb, b, bytesUnit, bytesConstants)
+}
+
+func (b *bytes) Scan(state fmt.ScanState, verb rune) error {
+	v, err := units.ScanValue(state, verb, bytesCodec)
+	if err != nil {
+		return err
+	}
+
+	*b = v
+	return nil
+}
+
+// counter exercises the helpers using an unsigned type.
+type counter uint64
+
+var counterCodec = units.MustCompile(units.Unit[counter]{
+	{1, []string{"B"}, []string{"byte"}},
+	{1 << 10, []string{"KiB"}, []string{"kibibyte"}},
+})
+
+func (c counter) Format(f fmt.State, verb rune) {
+	units.FormatValue(f, verb, c, counterCodec.Unit(), map[counter]string{1: "pkg.Byte", 1 << 10: "pkg.Kibibyte"})
+}
+
+func (c *counter) Scan(state fmt.ScanState, verb rune) error {
+	v, err := units.ScanValue(state, verb, counterCodec)
+	if err != nil {
+		return err
+	}
+
+	*c = v
+	return nil
+}
+
+func TestFormatValue(t *testing.T) {
+	value := bytes(1<<30 + 512<<20)
+
+	testCases := []struct {
+		format   string
+		value    bytes
+		expected string
+	}{
+		{"%v", value, "1GiB512MiB"},
+		{"%s", value, "1GiB512MiB"},
+		{"%.2v", value, "1.50GiB"},
+		{"%.0v", 1<<30 + 768<<20, "2GiB"},
+		{"%+v", value, "1 gibibyte 512 mebibytes"},
+		{"%+.1v", value, "1.5 gibibytes"},
+		{"%#v", value, "pkg.Mebibyte*1536"},
+		{"%#v", 3 << 30, "pkg.Gibibyte*3"},
+		{"%#v", 1 << 30, "pkg.Gibibyte"},
+		{"%#v", -(3 << 30), "pkg.Gibibyte*-3"},
+		{"%#v", 1001, "pkg.Byte*1001"},
+		{"%#v", 0, "units_test.bytes(0)"},
+		{"%q", value, `"1GiB512MiB"`},
+		{"%d", value, "1610612736"},
+		{"%x", 255, "ff"},
+		{"%+d", 5, "+5"},
+		{"%06d", 5, "000005"},
+		{"%12v", value, "  1GiB512MiB"},
+		{"%-12v|", value, "1GiB512MiB  |"},
+		{"%z", 1, "%!z(units_test.bytes=1B)"},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, fmt.Sprintf(testCase.format, testCase.value), testCase.format)
+	}
+
+	require.Equal(t, "[1KiB 2B]", fmt.Sprint([]bytes{1 << 10, 2}))
+
+	max := counter(math.MaxUint64)
+	require.Equal(t, "18446744073709551615", fmt.Sprintf("%d", max))
+	require.Equal(t, "pkg.Byte*18446744073709551615", fmt.Sprintf("%#v", max))
+	require.Equal(t, "pkg.Kibibyte*3", fmt.Sprintf("%#v", counter(3<<10)))
+	require.Equal(t, "units_test.counter(0)", fmt.Sprintf("%#v", counter(0)))
+	require.Equal(t, "18014398509481983KiB1023B", fmt.Sprintf("%v", max))
+}
+
+func TestScanValue(t *testing.T) {
+	var (
+		a, b bytes
+		name string
+	)
+
+	n, err := fmt.Sscanf("cache=1GiB512MiB disk 2KiB", "cache=%v %s %v", &a, &name, &b)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.Equal(t, bytes(1<<30+512<<20), a)
+	require.Equal(t, "disk", name)
+	require.Equal(t, bytes(2<<10), b)
+
+	_, err = fmt.Sscan("  3MiB\n", &a)
+	require.NoError(t, err)
+	require.Equal(t, bytes(3<<20), a)
+
+	_, err = fmt.Sscanf("1024", "%d", &a)
+	require.NoError(t, err)
+	require.Equal(t, bytes(1024), a)
+
+	_, err = fmt.Sscan("1DNE", &a)
+	require.ErrorIs(t, err, units.ErrUnrecognizedSymbol)
+
+	_, err = fmt.Sscanf("1KiB", "%x", &a)
+	require.Error(t, err)
+
+	_, err = fmt.Sscan("", &a)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	_, err = fmt.Sscanf("99999999999999999999", "%d", &a)
+	require.Error(t, err)
+
+	var c counter
+	_, err = fmt.Sscanf("18446744073709551615 +2KiB", "%d %v", &c, new(counter))
+	require.NoError(t, err)
+	require.Equal(t, counter(math.MaxUint64), c)
+
+	_, err = fmt.Sscanf("-1", "%d", &c)
+	require.ErrorIs(t, err, units.ErrNegative)
+
+	_, err = fmt.Sscan("-1KiB", &c)
+	require.ErrorIs(t, err, units.ErrNegative)
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package quantitytest provides the encoding and database/sql checks shared by the tests of the quantity packages.
+package quantitytest
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/mjpitz/units"
+)
+
+// Encoding fails the test when the value of a quantity type isn't encoded as the provided text, both by MarshalText and
+// by the json package, or when the text, the provided inputs, or the value in its base unit (as a JSON number or a
+// JSON string holding only an integer) fail to decode back into it. Invalid input is expected to be rejected and JSON null
+// to be ignored, leaving the decoded value untouched.
+func Encoding[T interface {
+	units.Number
+	encoding.TextMarshaler
+}, P interface {
+	*T
+	encoding.TextUnmarshaler
+	json.Unmarshaler
+}](t testing.TB, value T, text string, inputs ...string) {
+	t.Helper()
+
+	marshaled, err := value.MarshalText()
+	if err != nil || string(marshaled) != text {
+		t.Errorf("marshaled %v as text %q (%v), expected %q", value, marshaled, err, text)
+	}
+
+	marshaled, err = json.Marshal(value)
+	if err != nil || string(marshaled) != strconv.Quote(text) {
+		t.Errorf("marshaled %v as JSON %s (%v), expected %q", value, marshaled, err, text)
+	}
+
+	number, err := json.Marshal(units.JSON[T]{Value: value})
+	if err != nil {
+		t.Fatalf("failed to marshal %v as a JSON number: %v", value, err)
+	}
+
+	var decoded T
+	if err := P(&decoded).UnmarshalText([]byte(text)); err != nil || decoded != value {
+		t.Errorf("unmarshaled text %q as %v (%v), expected %v", text, decoded, err, value)
+	}
+
+	raw := [][]byte{number}
+	for _, input := range append([]string{text, string(number)}, inputs...) {
+		raw = append(raw, []byte(strconv.Quote(input)))
+	}
+
+	for _, data := range raw {
+		var decoded T
+		if err := json.Unmarshal(data, P(&decoded)); err != nil || decoded != value {
+			t.Errorf("unmarshaled JSON %s as %v (%v), expected %v", data, decoded, err, value)
+		}
+	}
+
+	for _, data := range []string{`null`, `"100DNE"`, `1.5`, `true`} {
+		decoded := value
+		err := json.Unmarshal([]byte(data), P(&decoded))
+		if (err == nil) != (data == "null") || decoded != value {
+			t.Errorf("unmarshaled JSON %s as %v (%v), expected it to be left untouched", data, decoded, err)
+		}
+	}
+}
+
+// SQL fails the test when the value of a quantity type isn't stored as an integer in its base unit, both by its Value
+// method and by database/sql, or when units.SQLString doesn't store it as the provided text. Both units.SQL and
+// units.SQLString are expected to scan the value back from the integer, the text, the provided inputs and the integer
+// rendered as a string, given as either string or []byte columns, and to reject NULL and invalid input.
+func SQL[T interface {
+	units.Number
+	driver.Valuer
+}](t testing.TB, value T, text string, inputs ...string) {
+	t.Helper()
+
+	base, err := units.Convert[int64](value)
+	if err != nil {
+		t.Fatalf("failed to convert %v to int64: %v", value, err)
+	}
+
+	stored, err := value.Value()
+	if err != nil || stored != base {
+		t.Errorf("stored %v as %#v (%v), expected %d", value, stored, err, base)
+	}
+
+	stored, err = driver.DefaultParameterConverter.ConvertValue(value)
+	if err != nil || stored != base {
+		t.Errorf("converted %v to %#v (%v), expected %d", value, stored, err, base)
+	}
+
+	stored, err = units.SQLString[T]{Quantity: value}.Value()
+	if err != nil || stored != text {
+		t.Errorf("stored %v as %#v (%v), expected %q", value, stored, err, text)
+	}
+
+	sources := []interface{}{base}
+	for _, input := range append([]string{text, strconv.FormatInt(base, 10)}, inputs...) {
+		sources = append(sources, input, []byte(input))
+	}
+
+	for _, src := range sources {
+		var scanned units.SQL[T]
+		if err := scanned.Scan(src); err != nil || scanned.Quantity != value {
+			t.Errorf("scanned %#v as %v (%v), expected %v", src, scanned.Quantity, err, value)
+		}
+
+		var scannedString units.SQLString[T]
+		if err := scannedString.Scan(src); err != nil || scannedString.Quantity != value {
+			t.Errorf("scanned %#v into SQLString as %v (%v), expected %v", src, scannedString.Quantity, err, value)
+		}
+	}
+
+	for _, src := range []interface{}{nil, "100DNE", 1.5, true} {
+		var scanned units.SQL[T]
+		if err := scanned.Scan(src); err == nil {
+			t.Errorf("scanned %#v as %v, expected an error", src, scanned.Quantity)
+		}
+
+		var scannedString units.SQLString[T]
+		if err := scannedString.Scan(src); err == nil {
+			t.Errorf("scanned %#v into SQLString as %v, expected an error", src, scannedString.Quantity)
+		}
+	}
+}
@@ -21,6 +21,9 @@
 package length
 
 import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
 	"sort"
 
 	"github.com/mjpitz/units"
@@ -36,8 +39,9 @@ func (u Length) As(other Length) float64 {
 	return float64(u) / float64(other)
 }
 
+// Set implements flag.Value, rounding fractions of a nanometer half to even, as units.Unit.Parse does.
 func (u *Length) Set(val string) error {
-	v, err := all.Parse(val)
+	v, err := codec.Parse(val)
 	if err != nil {
 		return err
 	}
@@ -50,6 +54,53 @@ func (u Length) String() string {
 	return SI.Format(u)
 }
 
+// Format implements fmt.Formatter. See units.FormatValue for the supported verbs.
+func (u Length) Format(f fmt.State, verb rune) {
+	units.FormatValue(f, verb, u, SI, constants)
+}
+
+// Scan implements fmt.Scanner, accepting the same values as Set.
+func (u *Length) Scan(state fmt.ScanState, verb rune) error {
+	v, err := units.ScanValue(state, verb, codec)
+	if err != nil {
+		return err
+	}
+
+	*u = v
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler using the same format as String.
+func (u Length) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting the same values as Set.
+func (u *Length) UnmarshalText(text []byte) error {
+	return u.Set(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting either a string (see Set) or a bare integer number of nanometers.
+func (u *Length) UnmarshalJSON(data []byte) error {
+	return units.UnmarshalJSON(data, u, codec)
+}
+
+// MarshalYAML implements yaml.Marshaler, emitting the same format as String.
+func (u Length) MarshalYAML() (interface{}, error) {
+	return u.String(), nil
+}
+
+// UnmarshalYAML implements the obsolete form of yaml.Unmarshaler, accepting a string (see Set) or a bare integer number
+// of nanometers. Errors don't report where the value appears in the document (see unitsyaml.Quantity).
+func (u *Length) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return units.UnmarshalYAML(unmarshal, u, codec)
+}
+
+// Value implements driver.Valuer, storing the value as an integer number of nanometers (see units.SQL).
+func (u Length) Value() (driver.Value, error) {
+	return int64(u), nil
+}
+
 const (
 	Nanometer  Length = 1
 	Micrometer        = 1000 * Nanometer
@@ -69,33 +120,70 @@ const (
 	League = 3 * Mile
 )
 
+// AstronomicalUnit returns the length of an astronomical unit. AstronomicalUnit and LightYear exceed the range of
+// Length and can only be used with BigSI. Each call returns a new *big.Int that the caller may modify.
+func AstronomicalUnit() *big.Int {
+	return new(big.Int).Mul(units.ToBig(Meter), big.NewInt(149597870700))
+}
+
+// LightYear returns the length of a light-year. See AstronomicalUnit.
+func LightYear() *big.Int {
+	return new(big.Int).Mul(units.ToBig(Meter), big.NewInt(9460730472580800))
+}
+
 var (
 	SI = units.Unit[Length]{
-		{Nanometer, []string{"nm"}},
-		{Micrometer, []string{"μm", "um"}},
-		{Millimeter, []string{"mm"}},
-		{Centimeter, []string{"cm"}},
-		{Decimeter, []string{"dm"}},
-		{Meter, []string{"m"}},
-		{Decameter, []string{"dam"}},
-		{Hectometer, []string{"hm"}},
-		{Kilometer, []string{"km"}},
+		{Nanometer, []string{"nm"}, []string{"nanometer"}},
+		{Micrometer, []string{"μm", "um"}, []string{"micrometer"}},
+		{Millimeter, []string{"mm"}, []string{"millimeter"}},
+		{Centimeter, []string{"cm"}, []string{"centimeter"}},
+		{Decimeter, []string{"dm"}, []string{"decimeter"}},
+		{Meter, []string{"m"}, []string{"meter"}},
+		{Decameter, []string{"dam"}, []string{"decameter"}},
+		{Hectometer, []string{"hm"}, []string{"hectometer"}},
+		{Kilometer, []string{"km"}, []string{"kilometer"}},
 	}
 
 	Imperial = units.Unit[Length]{
-		{Inch, []string{"in", "\""}},
-		{Foot, []string{"ft", "'"}},
-		{Yard, []string{"yd"}},
-		{Mile, []string{"mi"}},
-		{League, []string{"lea"}},
+		{Inch, []string{"in", "\"", "″"}, []string{"inch", "inches"}},
+		{Foot, []string{"ft", "'", "′"}, []string{"foot", "feet"}},
+		{Yard, []string{"yd"}, []string{"yard"}},
+		{Mile, []string{"mi"}, []string{"mile"}},
+		{League, []string{"lea"}, []string{"league"}},
 	}
 
-	all units.Unit[Length]
+	// BigSI extends SI with astronomical distances beyond the range of Length.
+	BigSI = units.NewBigUnit(SI,
+		units.BigSymbol{AstronomicalUnit(), []string{"au"}, []string{"astronomical unit"}},
+		units.BigSymbol{LightYear(), []string{"ly"}, []string{"light-year"}},
+	)
+
+	all   units.Unit[Length]
+	codec *units.Codec[Length]
+
+	// constants maps sizes to the names of the constants declaring them for rendering Go syntax
+	constants = map[Length]string{
+		Nanometer:  "length.Nanometer",
+		Micrometer: "length.Micrometer",
+		Millimeter: "length.Millimeter",
+		Centimeter: "length.Centimeter",
+		Decimeter:  "length.Decimeter",
+		Meter:      "length.Meter",
+		Decameter:  "length.Decameter",
+		Hectometer: "length.Hectometer",
+		Kilometer:  "length.Kilometer",
+		Thou:       "length.Thou",
+		Inch:       "length.Inch",
+		Foot:       "length.Foot",
+		Yard:       "length.Yard",
+		Mile:       "length.Mile",
+		League:     "length.League",
+	}
 )
 
 func init() {
 	all = append(all, SI...)
-	all = append(all, units.Symbol[Length]{Thou, []string{"th"}})
+	all = append(all, units.Symbol[Length]{Thou, []string{"th"}, []string{"thou", "thou"}})
 	all = append(all, Imperial...)
 
 	// ensure all is sorted
@@ -103,4 +191,6 @@ func init() {
 	sort.Slice(all, func(i, j int) bool {
 		return all[i].Size < all[j].Size
 	})
+
+	codec = units.MustCompile(all)
 }
@@ -21,10 +21,14 @@
 package length_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/mjpitz/units"
 	"github.com/mjpitz/units/length"
+	"github.com/mjpitz/units/internal/quantitytest"
+	"github.com/mjpitz/units/unitstest"
 )
 
 func TestLength(t *testing.T) {
@@ -52,14 +56,29 @@ func TestLength(t *testing.T) {
 	require.Equal(t, "1mm", length.Millimeter.String())
 	require.Equal(t, "1μm", length.Micrometer.String())
 	require.Equal(t, "1nm", length.Nanometer.String())
+	require.Equal(t, "-1km5hm", (-length.Kilometer - 500*length.Meter).String())
 
-	require.Equal(t, "", length.Imperial.Format(0))
+	require.Equal(t, "0in", length.Imperial.Format(0))
+	require.Equal(t, "0nm", length.Length(0).String())
 	require.Equal(t, "1lea", length.Imperial.Format(length.League))
 	require.Equal(t, "1mi", length.Imperial.Format(length.Mile))
 	require.Equal(t, "1yd", length.Imperial.Format(length.Yard))
 	require.Equal(t, "1ft", length.Imperial.Format(length.Foot))
 	require.Equal(t, "1in", length.Imperial.Format(length.Inch))
 
+	// fractions of a thou are parsed back to the nearest nanometer
+	var fraction length.Length
+	require.NoError(t, fraction.Set(length.Imperial.Format(7*length.Nanometer)))
+	require.Equal(t, 7*length.Nanometer, fraction)
+
+	long := length.Mile + 1234*length.Yard + length.Foot + 5*length.Inch
+	require.Equal(t, "1mi1234yd1ft5in", length.Imperial.Format(long))
+	require.Equal(t, "1mi1234yd", length.Imperial.Format(long, units.MaxComponents(2)))
+	require.Equal(t, "1mi1235yd", length.Imperial.Format(long+length.Inch, units.MaxComponents(2)))
+	require.Equal(t, "1mi1234yd1ft5in", length.Imperial.Format(long, units.MaxComponents(4)))
+	require.Equal(t, "1 mile 1234 yards 1 foot 5 inches", length.Imperial.Format(long, units.Verbose()))
+	require.Equal(t, "2 feet 1 inch", length.Imperial.Format(2*length.Foot+length.Inch, units.Verbose()))
+
 	basic := 100 * length.Meter
 
 	testCases := []struct {
@@ -72,7 +91,17 @@ func TestLength(t *testing.T) {
 		{"+1km", false, length.Kilometer},
 		{"10km", false, 10 * length.Kilometer},
 		{"1km1hm1dam", false, length.Kilometer + length.Hectometer + length.Decameter},
+		{"9000000km 2000km 1000000km", true, 0},
+		{"5'11\"", false, 5*length.Foot + 11*length.Inch},
+		{"5′11″", false, 5*length.Foot + 11*length.Inch},
+		{"1μm", false, length.Micrometer},
+		{"1\u00b5m", false, length.Micrometer},
+		{"3 kilometers", false, 3 * length.Kilometer},
+		{"1 foot", false, length.Foot},
+		{"2 feet 3 inches", false, 2*length.Foot + 3*length.Inch},
+		{"1 kilometer 500 meters", false, length.Kilometer + 500*length.Meter},
 		{"100DNE", true, 0},
+		{"5", true, 0},
 		{"BAD", true, 0},
 	}
 
@@ -87,4 +116,70 @@ func TestLength(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, testCase.expected, basic)
 	}
+
+	_, err := units.Add(9000000*length.Kilometer, 2000*length.Kilometer)
+	require.NoError(t, err)
+
+	_, err = units.Add(9000000*length.Kilometer, 300000*length.Kilometer)
+	require.ErrorIs(t, err, units.ErrOverflow)
+}
+
+func TestEncoding(t *testing.T) {
+	quantitytest.Encoding(t, 1500*length.Meter, "1km5hm", "1.5 kilometers")
+}
+
+func TestSQL(t *testing.T) {
+	quantitytest.SQL(t, 1500*length.Meter, "1km5hm", "1.5 kilometers")
+}
+
+func TestFormatter(t *testing.T) {
+	value := 1500 * length.Meter
+
+	testCases := []struct {
+		format   string
+		expected string
+	}{
+		{"%v", "1km5hm"},
+		{"%.1v", "1.5km"},
+		{"%+v", "1 kilometer 5 hectometers"},
+		{"%#v", "length.Hectometer*15"},
+		{"%d", "1500000000000"},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, fmt.Sprintf(testCase.format, value), testCase.format)
+	}
+
+	require.Equal(t, "length.Foot*2", fmt.Sprintf("%#v", 2*length.Foot))
+
+	var scanned length.Length
+	_, err := fmt.Sscanf("limit=1.5km", "limit=%v", &scanned)
+	require.NoError(t, err)
+	require.Equal(t, value, scanned)
+}
+
+func TestBig(t *testing.T) {
+	distance, err := length.BigSI.Parse("4.2465 light-years")
+	require.NoError(t, err)
+	require.Equal(t, "4ly15588au138453020km", length.BigSI.Format(distance, units.MaxComponents(3)))
+	require.Equal(t, "4.25ly", length.BigSI.Format(distance, units.Largest(), units.Precision(2)))
+
+	au, err := length.BigSI.As(distance, "au")
+	require.NoError(t, err)
+	require.InDelta(t, 268553.2, au, 0.1)
+
+	_, err = units.FromBig[length.Length](distance)
+	require.ErrorIs(t, err, units.ErrOverflow)
+
+	require.NoError(t, length.BigSI.Validate())
+	require.Equal(t, "1ly", length.BigSI.Format(length.LightYear()))
+
+	marathon, err := units.FromBig[length.Length](units.ToBig(42195 * length.Meter))
+	require.NoError(t, err)
+	require.Equal(t, "42km1hm9dam5m", marathon.String())
+}
+
+func TestUnits(t *testing.T) {
+	unitstest.Validate(t, length.SI, length.Imperial)
+	unitstest.RoundTrip(t, length.SI, length.Imperial)
 }
@@ -0,0 +1,232 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units
+
+import (
+	"strings"
+	"sync"
+)
+
+// Locale describes how numbers and symbols are written in a particular language or region. A Locale is an Option, so it
+// can be passed directly to Format and Parse (for example, Format(value, units.German) renders "1,5 GiB"). When
+// parsing, the decimal and grouping separators of the Locale replace the '.' and ',' used by default, and localized
+// symbols are accepted alongside the labels declared by the Unit.
+type Locale struct {
+	// Tag is the BCP 47 language tag identifying the Locale (for example, "de-DE").
+	Tag string
+	// DecimalSeparator separates the whole part of a number from its fraction.
+	DecimalSeparator string
+	// GroupSeparator separates groups of three digits in the whole part of a number. Digits are not grouped when empty.
+	GroupSeparator string
+	// Space determines if a space is placed between a number and its symbol (for example, "1,5 GiB").
+	Space bool
+	// Symbols maps the preferred labels of a Unit to their localized form (for example, "GB" to "Go").
+	Symbols map[string]string
+}
+
+var (
+	// English formats numbers using a '.' decimal separator and ',' digit grouping (for example, "1,536MiB").
+	English = &Locale{
+		Tag:              "en-US",
+		DecimalSeparator: ".",
+		GroupSeparator:   ",",
+	}
+
+	// German formats numbers using a ',' decimal separator and '.' digit grouping (for example, "1.536 MiB").
+	German = &Locale{
+		Tag:              "de-DE",
+		DecimalSeparator: ",",
+		GroupSeparator:   ".",
+		Space:            true,
+	}
+
+	// French formats numbers using a ',' decimal separator and narrow no-break space digit grouping. Bytes are written as
+	// octets (for example, "1,5 Gio").
+	French = &Locale{
+		Tag:              "fr-FR",
+		DecimalSeparator: ",",
+		GroupSeparator:   " ",
+		Space:            true,
+		Symbols: map[string]string{
+			"B":   "o",
+			"kB":  "ko",
+			"KiB": "Kio",
+			"MB":  "Mo",
+			"MiB": "Mio",
+			"GB":  "Go",
+			"GiB": "Gio",
+			"TB":  "To",
+			"TiB": "Tio",
+			"PB":  "Po",
+			"PiB": "Pio",
+		},
+	}
+
+	// Japanese formats numbers using a '.' decimal separator and ',' digit grouping (for example, "1,536MiB").
+	Japanese = &Locale{
+		Tag:              "ja-JP",
+		DecimalSeparator: ".",
+		GroupSeparator:   ",",
+	}
+)
+
+var (
+	localesMu sync.RWMutex
+	locales   = []*Locale{English, German, French, Japanese}
+)
+
+// RegisterLocale makes the Locale available through LookupLocale using its Tag. Registering a Locale with the same Tag
+// as an existing one replaces it.
+func RegisterLocale(locale *Locale) {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+
+	for i, existing := range locales {
+		if strings.EqualFold(existing.Tag, locale.Tag) {
+			locales[i] = locale
+			return
+		}
+	}
+
+	locales = append(locales, locale)
+}
+
+// LookupLocale returns the registered Locale for the provided language tag. Tags are compared without regard to case
+// and may use '_' in place of '-' (for example, "fr_fr"). When no Locale matches the full tag, the first Locale sharing
+// its language is returned (for example, "fr-CA" or "fr" resolves to French).
+func LookupLocale(tag string) (*Locale, bool) {
+	tag = strings.ReplaceAll(tag, "_", "-")
+	language, _, _ := strings.Cut(tag, "-")
+
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+
+	for _, locale := range locales {
+		if strings.EqualFold(locale.Tag, tag) {
+			return locale, true
+		}
+	}
+
+	for _, locale := range locales {
+		if prefix, _, _ := strings.Cut(locale.Tag, "-"); strings.EqualFold(prefix, language) {
+			return locale, true
+		}
+	}
+
+	return nil, false
+}
+
+// Apply configures Format and Parse to use the Locale.
+func (l *Locale) Apply(opts *Options) {
+	opts.Locale = l
+}
+
+// symbol returns the localized form of the label.
+func (l *Locale) symbol(label string) string {
+	if l == nil {
+		return label
+	}
+
+	if localized, ok := l.Symbols[label]; ok {
+		return localized
+	}
+
+	return label
+}
+
+// number localizes a number rendered by strconv by replacing its decimal point and grouping the digits of its whole
+// part.
+func (l *Locale) number(number string) string {
+	if l == nil {
+		return number
+	}
+
+	whole := 0
+	for whole < len(number) && isDigit(number[whole]) {
+		whole++
+	}
+
+	var sb strings.Builder
+	for i := 0; i < whole; i++ {
+		if i > 0 && (whole-i)%3 == 0 {
+			sb.WriteString(l.GroupSeparator)
+		}
+
+		sb.WriteByte(number[i])
+	}
+
+	rest := number[whole:]
+	if strings.HasPrefix(rest, ".") {
+		sb.WriteString(l.DecimalSeparator)
+		rest = rest[1:]
+	}
+
+	sb.WriteString(rest)
+	return sb.String()
+}
+
+// scan returns the end of the measure starting at i, recognizing the separators of the Locale in place of '.' and ','.
+// Grouping separators are only considered part of the measure when followed by a digit so that locales grouping digits
+// with a space can still separate measures from their symbols.
+func (l *Locale) scan(val string, i int) int {
+	if l == nil {
+		return scanMeasure(val, i)
+	}
+
+	start := i
+	for i < len(val) {
+		switch {
+		case isDigit(val[i]) || (val[i] == '_' && i > start):
+			i++
+		case l.DecimalSeparator != "" && strings.HasPrefix(val[i:], l.DecimalSeparator):
+			i += len(l.DecimalSeparator)
+		case l.GroupSeparator != "" && i > start && strings.HasPrefix(val[i:], l.GroupSeparator) &&
+			i+len(l.GroupSeparator) < len(val) && isDigit(val[i+len(l.GroupSeparator)]):
+			i += len(l.GroupSeparator)
+		default:
+			if i == start {
+				return i
+			}
+
+			return scanExponent(val, i)
+		}
+	}
+
+	return i
+}
+
+// normalize rewrites a measure written using the separators of the Locale to use '.' and ',' instead.
+func (l *Locale) normalize(measure string) string {
+	if l == nil {
+		return measure
+	}
+
+	var pairs []string
+	if l.GroupSeparator != "" {
+		pairs = append(pairs, l.GroupSeparator, ",")
+	}
+
+	if l.DecimalSeparator != "" {
+		pairs = append(pairs, l.DecimalSeparator, ".")
+	}
+
+	return strings.NewReplacer(pairs...).Replace(measure)
+}
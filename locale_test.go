@@ -0,0 +1,153 @@
+package units_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mjpitz/units"
+)
+
+var octets = units.Unit[int64]{
+	{1, []string{"B"}, []string{"byte"}},
+	{1000, []string{"kB"}, []string{"kilobyte"}},
+	{1 << 10, []string{"KiB"}, []string{"kibibyte"}},
+	{1 << 30, []string{"GiB"}, []string{"gibibyte"}},
+}
+
+func TestLocale_Format(t *testing.T) {
+	value := int64(1<<30 + 512<<20)
+
+	testCases := []struct {
+		locale   *units.Locale
+		opts     []units.Option
+		value    int64
+		expected string
+	}{
+		{units.English, []units.Option{units.Largest()}, value, "1.5GiB"},
+		{units.German, []units.Option{units.Largest()}, value, "1,5 GiB"},
+		{units.French, []units.Option{units.Largest()}, value, "1,5 Gio"},
+		{units.Japanese, []units.Option{units.Largest()}, value, "1.5GiB"},
+		{units.English, []units.Option{units.Fixed("KiB")}, value, "1,572,864KiB"},
+		{units.German, []units.Option{units.Fixed("KiB")}, value, "1.572.864 KiB"},
+		{units.French, []units.Option{units.Fixed("KiB")}, value, "1 572 864 Kio"},
+		{units.German, nil, 1<<30 + 1001, "1 GiB 1.001 B"},
+		{units.French, nil, 1<<30 + 1001, "1 Gio 1\u202f001 o"},
+		{units.English, nil, 1<<30 + 1001, "1GiB1,001B"},
+		{units.German, []units.Option{units.Verbose(), units.Largest()}, value, "1,5 gibibytes"},
+		{units.German, []units.Option{units.Largest(), units.Precision(3)}, -value, "-1,500 GiB"},
+		{units.French, nil, 0, "0 o"},
+		{units.French, []units.Option{units.ZeroSymbol("GiB")}, 0, "0 Gio"},
+		{units.German, []units.Option{units.Largest(), units.Format('e'), units.Precision(1)}, value, "1,5e+00 GiB"},
+	}
+
+	for _, testCase := range testCases {
+		opts := append([]units.Option{testCase.locale}, testCase.opts...)
+		require.Equal(t, testCase.expected, binary.Format(testCase.value, opts...))
+	}
+
+	require.Equal(t, "1,001 kB", octets.Format(1001, units.Largest(), units.German))
+	require.Equal(t, "1,001ko", octets.Format(1001, units.Largest(), &units.Locale{
+		DecimalSeparator: ",",
+		Symbols:          map[string]string{"kB": "ko"},
+	}))
+}
+
+func TestLocale_Parse(t *testing.T) {
+	testCases := []struct {
+		locale   *units.Locale
+		input    string
+		expected int64
+	}{
+		{units.English, "1.5GiB", 1<<30 + 512<<20},
+		{units.English, "1,024KiB", 1 << 20},
+		{units.German, "1,5 GiB", 1<<30 + 512<<20},
+		{units.German, "1.024 KiB", 1 << 20},
+		{units.German, "1 GiB 512 MiB", 1<<30 + 512<<20},
+		{units.French, "1,5 Gio", 1<<30 + 512<<20},
+		{units.French, "1 024 Kio", 1 << 20},
+		{units.French, "1 Gio 1 o", 1<<30 + 1},
+		{units.French, "1GiB 1B", 1<<30 + 1},
+		{units.French, "1 gibibyte 1 byte", 1<<30 + 1},
+		{units.Japanese, "1.5GiB", 1<<30 + 512<<20},
+	}
+
+	for _, testCase := range testCases {
+		parsed, err := binary.Parse(testCase.input, testCase.locale)
+		require.NoError(t, err, testCase.input)
+		require.Equal(t, testCase.expected, parsed, testCase.input)
+
+		codec := units.MustCompile(binary, testCase.locale)
+		parsed, err = codec.Parse(testCase.input)
+		require.NoError(t, err, testCase.input)
+		require.Equal(t, testCase.expected, parsed, testCase.input)
+	}
+
+	_, err := binary.Parse("1.5 GiB", units.German)
+	require.ErrorIs(t, err, units.ErrInvalidNumber)
+
+	_, err = binary.Parse("1 Gio")
+	require.ErrorIs(t, err, units.ErrUnrecognizedSymbol)
+
+	parsed, err := units.MustCompile(binary).Parse("1,5 gio", units.French, units.IgnoreCase())
+	require.NoError(t, err)
+	require.Equal(t, int64(1<<30+512<<20), parsed)
+
+	// values round trip through each of the built-in locales
+	for _, locale := range []*units.Locale{units.English, units.German, units.French, units.Japanese} {
+		for _, value := range []int64{1, 1<<30 + 1001, 1234 << 20} {
+			for _, opts := range [][]units.Option{nil, {units.Fixed("KiB")}, {units.Fixed("B")}} {
+				opts = append(opts, locale)
+
+				parsed, err := binary.Parse(binary.Format(value, opts...), locale)
+				require.NoError(t, err)
+				require.Equal(t, value, parsed)
+			}
+		}
+	}
+}
+
+func TestLookupLocale(t *testing.T) {
+	for tag, expected := range map[string]*units.Locale{
+		"en-US": units.English,
+		"de-DE": units.German,
+		"de_de": units.German,
+		"de-AT": units.German,
+		"fr":    units.French,
+		"fr-CA": units.French,
+		"ja-JP": units.Japanese,
+	} {
+		locale, ok := units.LookupLocale(tag)
+		require.True(t, ok, tag)
+		require.Same(t, expected, locale, tag)
+	}
+
+	_, ok := units.LookupLocale("xx-XX")
+	require.False(t, ok)
+
+	t.Run("register", func(t *testing.T) {
+		t.Cleanup(units.SaveLocales())
+
+		swiss := &units.Locale{Tag: "de-CH", DecimalSeparator: ".", GroupSeparator: "'", Space: true}
+		units.RegisterLocale(swiss)
+
+		locale, ok := units.LookupLocale("de-ch")
+		require.True(t, ok)
+		require.Same(t, swiss, locale)
+		require.Equal(t, "1'572'864 KiB", binary.Format(1536<<20, units.Fixed("KiB"), locale))
+
+		parsed, err := binary.Parse("1'572'864 KiB", locale)
+		require.NoError(t, err)
+		require.Equal(t, int64(1536<<20), parsed)
+
+		// German is still preferred for other regions
+		locale, ok = units.LookupLocale("de-AT")
+		require.True(t, ok)
+		require.Same(t, units.German, locale)
+	})
+
+	// registered locales are removed once the test completes
+	locale, ok := units.LookupLocale("de-CH")
+	require.True(t, ok)
+	require.Same(t, units.German, locale)
+}
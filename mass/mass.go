@@ -21,6 +21,8 @@
 package mass
 
 import (
+	"database/sql/driver"
+	"fmt"
 	"sort"
 
 	"github.com/mjpitz/units"
@@ -36,8 +38,9 @@ func (u Mass) As(other Mass) float64 {
 	return float64(u) / float64(other)
 }
 
+// Set implements flag.Value, rounding fractions of a nanogram half to even, as units.Unit.Parse does.
 func (u *Mass) Set(val string) error {
-	v, err := all.Parse(val)
+	v, err := codec.Parse(val)
 	if err != nil {
 		return err
 	}
@@ -50,6 +53,53 @@ func (u Mass) String() string {
 	return SI.Format(u)
 }
 
+// Format implements fmt.Formatter. See units.FormatValue for the supported verbs.
+func (u Mass) Format(f fmt.State, verb rune) {
+	units.FormatValue(f, verb, u, SI, constants)
+}
+
+// Scan implements fmt.Scanner, accepting the same values as Set.
+func (u *Mass) Scan(state fmt.ScanState, verb rune) error {
+	v, err := units.ScanValue(state, verb, codec)
+	if err != nil {
+		return err
+	}
+
+	*u = v
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler using the same format as String.
+func (u Mass) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting the same values as Set.
+func (u *Mass) UnmarshalText(text []byte) error {
+	return u.Set(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting either a string (see Set) or a bare integer number of nanograms.
+func (u *Mass) UnmarshalJSON(data []byte) error {
+	return units.UnmarshalJSON(data, u, codec)
+}
+
+// MarshalYAML implements yaml.Marshaler, emitting the same format as String.
+func (u Mass) MarshalYAML() (interface{}, error) {
+	return u.String(), nil
+}
+
+// UnmarshalYAML implements the obsolete form of yaml.Unmarshaler, accepting a string (see Set) or a bare integer number
+// of nanograms. Errors don't report where the value appears in the document (see unitsyaml.Quantity).
+func (u *Mass) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return units.UnmarshalYAML(unmarshal, u, codec)
+}
+
+// Value implements driver.Valuer, storing the value as an integer number of nanograms (see units.SQL).
+func (u Mass) Value() (driver.Value, error) {
+	return int64(u), nil
+}
+
 const (
 	Nanogram  Mass = 1
 	Microgram      = 1000 * Nanogram
@@ -82,47 +132,79 @@ const (
 
 var (
 	SI = units.Unit[Mass]{
-		{Nanogram, []string{"ng"}},
-		{Microgram, []string{"μg", "ug"}},
-		{Milligram, []string{"mg"}},
-		{Centigram, []string{"cg"}},
-		{Decigram, []string{"dg"}},
-		{Gram, []string{"g"}},
-		{Decagram, []string{"dag"}},
-		{Hectogram, []string{"hg"}},
-		{Kilogram, []string{"kg"}},
+		{Nanogram, []string{"ng"}, []string{"nanogram"}},
+		{Microgram, []string{"μg", "ug"}, []string{"microgram"}},
+		{Milligram, []string{"mg"}, []string{"milligram"}},
+		{Centigram, []string{"cg"}, []string{"centigram"}},
+		{Decigram, []string{"dg"}, []string{"decigram"}},
+		{Gram, []string{"g"}, []string{"gram"}},
+		{Decagram, []string{"dag"}, []string{"decagram"}},
+		{Hectogram, []string{"hg"}, []string{"hectogram"}},
+		{Kilogram, []string{"kg"}, []string{"kilogram"}},
 	}
 
 	Imperial = units.Unit[Mass]{
-		{Grain, []string{"gr"}},
-		{Dram, []string{"dr"}},
-		{Ounce, []string{"oz"}},
-		{Pound, []string{"lb"}},
-		{Stone, []string{"st"}},
-		{Quarter, []string{"qr"}},
-		{Hundredweight, []string{"cwt"}},
-		{Ton, []string{"ton"}},
+		{Grain, []string{"gr"}, []string{"grain"}},
+		{Dram, []string{"dr"}, []string{"dram"}},
+		{Ounce, []string{"oz"}, []string{"ounce"}},
+		{Pound, []string{"lb"}, []string{"pound"}},
+		{Stone, []string{"st"}, []string{"stone", "stone"}},
+		{Quarter, []string{"qr"}, []string{"quarter"}},
+		{Hundredweight, []string{"cwt"}, []string{"hundredweight", "hundredweight"}},
+		{Ton, []string{"ton"}, []string{"ton"}},
 	}
 
 	// The Troy unit of measure is frequently used when dealing with precious metals.
 	Troy = units.Unit[Mass]{
-		{Grain, []string{"gr"}},
-		{TroyPennyweight, []string{"dw t"}},
-		{TroyOunce, []string{"oz t"}},
-		{TroyPound, []string{"lb t"}},
+		{Grain, []string{"gr"}, []string{"grain"}},
+		{TroyPennyweight, []string{"dw t"}, []string{"pennyweight"}},
+		{TroyOunce, []string{"oz t"}, []string{"troy ounce"}},
+		{TroyPound, []string{"lb t"}, []string{"troy pound"}},
 	}
 
 	// USCanada is a special format that uses smaller values for Hundredweight and Ton.
 	USCanada = units.Unit[Mass]{
-		{Grain, []string{"gr"}},
-		{Dram, []string{"dr"}},
-		{Ounce, []string{"oz"}},
-		{Pound, []string{"lb"}},
-		{USCanadaHundredweight, []string{"cwt"}},
-		{USCanadaTon, []string{"ton"}},
+		{Grain, []string{"gr"}, []string{"grain"}},
+		{Dram, []string{"dr"}, []string{"dram"}},
+		{Ounce, []string{"oz"}, []string{"ounce"}},
+		{Pound, []string{"lb"}, []string{"pound"}},
+		{USCanadaHundredweight, []string{"cwt"}, []string{"hundredweight", "hundredweight"}},
+		{USCanadaTon, []string{"ton"}, []string{"ton"}},
 	}
 
-	all units.Unit[Mass]
+	// FloatSI and FloatImperial measure continuous quantities (such as sensor readings) in fractional nanograms.
+	FloatSI       = units.NewFloatUnit[float64](SI)
+	FloatImperial = units.NewFloatUnit[float64](Imperial)
+
+	all   units.Unit[Mass]
+	codec *units.Codec[Mass]
+
+	// constants maps sizes to the names of the constants declaring them for rendering Go syntax
+	constants = map[Mass]string{
+		Nanogram:              "mass.Nanogram",
+		Microgram:             "mass.Microgram",
+		Milligram:             "mass.Milligram",
+		Centigram:             "mass.Centigram",
+		Decigram:              "mass.Decigram",
+		Gram:                  "mass.Gram",
+		Decagram:              "mass.Decagram",
+		Hectogram:             "mass.Hectogram",
+		Kilogram:              "mass.Kilogram",
+		Grain:                 "mass.Grain",
+		Dram:                  "mass.Dram",
+		Ounce:                 "mass.Ounce",
+		Pound:                 "mass.Pound",
+		Stone:                 "mass.Stone",
+		Quarter:               "mass.Quarter",
+		Hundredweight:         "mass.Hundredweight",
+		Ton:                   "mass.Ton",
+		USCanadaHundredweight: "mass.USCanadaHundredweight",
+		USCanadaTon:           "mass.USCanadaTon",
+		TroyPennyweight:       "mass.TroyPennyweight",
+		TroyOunce:             "mass.TroyOunce",
+		TroyPound:             "mass.TroyPound",
+		Slug:                  "mass.Slug",
+	}
 )
 
 func init() {
@@ -134,4 +216,6 @@ func init() {
 	sort.Slice(all, func(i, j int) bool {
 		return all[i].Size < all[j].Size
 	})
+
+	codec = units.MustCompile(all)
 }
@@ -21,10 +21,14 @@
 package mass_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/mjpitz/units"
 	"github.com/mjpitz/units/mass"
+	"github.com/mjpitz/units/internal/quantitytest"
+	"github.com/mjpitz/units/unitstest"
 )
 
 func TestMass(t *testing.T) {
@@ -54,7 +58,8 @@ func TestMass(t *testing.T) {
 	require.Equal(t, "1μg", mass.Microgram.String())
 	require.Equal(t, "1ng", mass.Nanogram.String())
 
-	require.Equal(t, "", mass.Imperial.Format(0))
+	require.Equal(t, "0gr", mass.Imperial.Format(0))
+	require.Equal(t, "0ng", mass.Mass(0).String())
 	require.Equal(t, "1ton", mass.Imperial.Format(mass.Ton))
 	require.Equal(t, "1cwt", mass.Imperial.Format(mass.Hundredweight))
 	require.Equal(t, "1qr", mass.Imperial.Format(mass.Quarter))
@@ -63,6 +68,11 @@ func TestMass(t *testing.T) {
 	require.Equal(t, "1oz", mass.Imperial.Format(mass.Ounce))
 	require.Equal(t, "1gr", mass.Imperial.Format(mass.Grain))
 
+	// fractions of a grain are parsed back to the nearest nanogram
+	var fraction mass.Mass
+	require.NoError(t, fraction.Set(mass.Imperial.Format(mass.Nanogram)))
+	require.Equal(t, mass.Nanogram, fraction)
+
 	basic := 100 * mass.Gram
 
 	testCases := []struct {
@@ -76,6 +86,7 @@ func TestMass(t *testing.T) {
 		{"10kg", false, 10 * mass.Kilogram},
 		{"1kg1hg1dag", false, mass.Kilogram + mass.Hectogram + mass.Decagram},
 		{"100DNE", true, 0},
+		{"5", true, 0},
 		{"BAD", true, 0},
 	}
 
@@ -91,3 +102,65 @@ func TestMass(t *testing.T) {
 		require.Equal(t, testCase.expected, basic)
 	}
 }
+
+func TestEncoding(t *testing.T) {
+	quantitytest.Encoding(t, 72*mass.Kilogram+350*mass.Gram, "72kg3hg5dag", "72.35kg")
+}
+
+func TestSQL(t *testing.T) {
+	quantitytest.SQL(t, 72*mass.Kilogram+350*mass.Gram, "72kg3hg5dag", "72.35kg")
+}
+
+func TestFormatter(t *testing.T) {
+	value := 1500 * mass.Gram
+
+	testCases := []struct {
+		format   string
+		expected string
+	}{
+		{"%v", "1kg5hg"},
+		{"%.1v", "1.5kg"},
+		{"%+v", "1 kilogram 5 hectograms"},
+		{"%#v", "mass.Hectogram*15"},
+		{"%d", "1500000000000"},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, fmt.Sprintf(testCase.format, value), testCase.format)
+	}
+
+	require.Equal(t, "mass.Pound", fmt.Sprintf("%#v", mass.Pound))
+
+	var scanned mass.Mass
+	_, err := fmt.Sscanf("limit=1.5kg", "limit=%v", &scanned)
+	require.NoError(t, err)
+	require.Equal(t, value, scanned)
+}
+
+func TestFloat(t *testing.T) {
+	reading, err := mass.FloatSI.Parse("72.35kg")
+	require.NoError(t, err)
+	require.Equal(t, 72.35*float64(mass.Kilogram), reading)
+	require.Equal(t, "72kg3hg5dag", mass.FloatSI.Format(reading))
+	require.Equal(t, "72.35kg", mass.FloatSI.Format(reading, units.Largest()))
+
+	kilograms, err := mass.FloatSI.As(reading, "kg")
+	require.NoError(t, err)
+	require.Equal(t, 72.35, kilograms)
+
+	require.Equal(t, "1.5ng", mass.FloatSI.Format(1.5))
+	require.Equal(t, "1lb8oz", mass.FloatImperial.Format(1.5*float64(mass.Pound)))
+
+	truncated, err := units.FromFloat[mass.Mass](reading+0.5, units.RoundTruncate)
+	require.NoError(t, err)
+	require.Equal(t, 72*mass.Kilogram+350*mass.Gram, truncated)
+
+	rounded, err := units.FromFloat[mass.Mass](2.5, units.RoundHalfEven)
+	require.NoError(t, err)
+	require.Equal(t, 2*mass.Nanogram, rounded)
+}
+
+func TestUnits(t *testing.T) {
+	unitstest.Validate(t, mass.SI, mass.Imperial, mass.Troy, mass.USCanada)
+	unitstest.RoundTrip(t, mass.SI, mass.Imperial, mass.Troy, mass.USCanada)
+}
@@ -21,6 +21,8 @@
 package network
 
 import (
+	"database/sql/driver"
+	"fmt"
 	"sort"
 
 	"github.com/mjpitz/units"
@@ -36,8 +38,21 @@ func (u Bandwidth) As(other Bandwidth) float64 {
 	return float64(u) / float64(other)
 }
 
+// Uint64 returns the bandwidth as an unsigned number of bits per second. units.ErrNegative is returned when the
+// bandwidth is negative.
+func (u Bandwidth) Uint64() (uint64, error) {
+	return units.Convert[uint64](u)
+}
+
+// FromUint64 converts an unsigned number of bits per second to a Bandwidth. units.ErrOverflow is returned when the
+// value exceeds the range of Bandwidth.
+func FromUint64(value uint64) (Bandwidth, error) {
+	return units.Convert[Bandwidth](value)
+}
+
+// Set implements flag.Value, rounding fractions of a bit per second half to even, as units.Unit.Parse does.
 func (u *Bandwidth) Set(val string) error {
-	v, err := all.Parse(val)
+	v, err := codec.Parse(val)
 	if err != nil {
 		return err
 	}
@@ -50,6 +65,53 @@ func (u Bandwidth) String() string {
 	return BinaryIEC.Format(u)
 }
 
+// Format implements fmt.Formatter. See units.FormatValue for the supported verbs.
+func (u Bandwidth) Format(f fmt.State, verb rune) {
+	units.FormatValue(f, verb, u, BinaryIEC, constants)
+}
+
+// Scan implements fmt.Scanner, accepting the same values as Set.
+func (u *Bandwidth) Scan(state fmt.ScanState, verb rune) error {
+	v, err := units.ScanValue(state, verb, codec)
+	if err != nil {
+		return err
+	}
+
+	*u = v
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler using the same format as String.
+func (u Bandwidth) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting the same values as Set.
+func (u *Bandwidth) UnmarshalText(text []byte) error {
+	return u.Set(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting either a string (see Set) or a bare integer number of bits per second.
+func (u *Bandwidth) UnmarshalJSON(data []byte) error {
+	return units.UnmarshalJSON(data, u, codec)
+}
+
+// MarshalYAML implements yaml.Marshaler, emitting the same format as String.
+func (u Bandwidth) MarshalYAML() (interface{}, error) {
+	return u.String(), nil
+}
+
+// UnmarshalYAML implements the obsolete form of yaml.Unmarshaler, accepting a string (see Set) or a bare integer number
+// of bits per second. Errors don't report where the value appears in the document (see unitsyaml.Quantity).
+func (u *Bandwidth) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return units.UnmarshalYAML(unmarshal, u, codec)
+}
+
+// Value implements driver.Valuer, storing the value as an integer number of bits per second (see units.SQL).
+func (u Bandwidth) Value() (driver.Value, error) {
+	return int64(u), nil
+}
+
 const (
 	Bit Bandwidth = 1
 
@@ -68,35 +130,52 @@ const (
 
 var (
 	Decimal = units.Unit[Bandwidth]{
-		{Bit, []string{"bps"}},
-		{Kilobit, []string{"kbps"}},
-		{Megabit, []string{"Mbps"}},
-		{Gigabit, []string{"Gbps"}},
-		{Terabit, []string{"Tbps"}},
-		{Petabit, []string{"Pbps"}},
+		{Bit, []string{"bps"}, []string{"bit per second", "bits per second"}},
+		{Kilobit, []string{"kbps"}, []string{"kilobit per second", "kilobits per second"}},
+		{Megabit, []string{"Mbps"}, []string{"megabit per second", "megabits per second"}},
+		{Gigabit, []string{"Gbps"}, []string{"gigabit per second", "gigabits per second"}},
+		{Terabit, []string{"Tbps"}, []string{"terabit per second", "terabits per second"}},
+		{Petabit, []string{"Pbps"}, []string{"petabit per second", "petabits per second"}},
 	}
 
 	BinaryIEC = units.Unit[Bandwidth]{
-		{Bit, []string{"bps"}},
-		{Kibibit, []string{"Kibps"}},
-		{Mebibit, []string{"Mibps"}},
-		{Gibibit, []string{"Gibps"}},
-		{Tebibit, []string{"Tibps"}},
-		{Pebibit, []string{"Pibps"}},
+		{Bit, []string{"bps"}, []string{"bit per second", "bits per second"}},
+		{Kibibit, []string{"Kibps"}, []string{"kibibit per second", "kibibits per second"}},
+		{Mebibit, []string{"Mibps"}, []string{"mebibit per second", "mebibits per second"}},
+		{Gibibit, []string{"Gibps"}, []string{"gibibit per second", "gibibits per second"}},
+		{Tebibit, []string{"Tibps"}, []string{"tebibit per second", "tebibits per second"}},
+		{Pebibit, []string{"Pibps"}, []string{"pebibit per second", "pebibits per second"}},
 	}
 
 	all = units.Unit[Bandwidth]{
-		{Bit, []string{"bps"}},
-		{Kilobit, []string{"kbps"}},
-		{Kibibit, []string{"Kibps"}},
-		{Megabit, []string{"Mbps"}},
-		{Mebibit, []string{"Mibps"}},
-		{Gigabit, []string{"Gbps"}},
-		{Gibibit, []string{"Gibps"}},
-		{Terabit, []string{"Tbps"}},
-		{Tebibit, []string{"Tibps"}},
-		{Petabit, []string{"Pbps"}},
-		{Pebibit, []string{"Pibps"}},
+		{Bit, []string{"bps"}, []string{"bit per second", "bits per second"}},
+		{Kilobit, []string{"kbps"}, []string{"kilobit per second", "kilobits per second"}},
+		{Kibibit, []string{"Kibps"}, []string{"kibibit per second", "kibibits per second"}},
+		{Megabit, []string{"Mbps"}, []string{"megabit per second", "megabits per second"}},
+		{Mebibit, []string{"Mibps"}, []string{"mebibit per second", "mebibits per second"}},
+		{Gigabit, []string{"Gbps"}, []string{"gigabit per second", "gigabits per second"}},
+		{Gibibit, []string{"Gibps"}, []string{"gibibit per second", "gibibits per second"}},
+		{Terabit, []string{"Tbps"}, []string{"terabit per second", "terabits per second"}},
+		{Tebibit, []string{"Tibps"}, []string{"tebibit per second", "tebibits per second"}},
+		{Petabit, []string{"Pbps"}, []string{"petabit per second", "petabits per second"}},
+		{Pebibit, []string{"Pibps"}, []string{"pebibit per second", "pebibits per second"}},
+	}
+
+	codec *units.Codec[Bandwidth]
+
+	// constants maps sizes to the names of the constants declaring them for rendering Go syntax
+	constants = map[Bandwidth]string{
+		Bit:     "network.Bit",
+		Kibibit: "network.Kibibit",
+		Mebibit: "network.Mebibit",
+		Gibibit: "network.Gibibit",
+		Tebibit: "network.Tebibit",
+		Pebibit: "network.Pebibit",
+		Kilobit: "network.Kilobit",
+		Megabit: "network.Megabit",
+		Gigabit: "network.Gigabit",
+		Terabit: "network.Terabit",
+		Petabit: "network.Petabit",
 	}
 )
 
@@ -106,4 +185,6 @@ func init() {
 	sort.Slice(all, func(i, j int) bool {
 		return all[i].Size < all[j].Size
 	})
+
+	codec = units.MustCompile(all)
 }
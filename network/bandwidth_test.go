@@ -21,10 +21,15 @@
 package network_test
 
 import (
+	"fmt"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/mjpitz/units"
 	"github.com/mjpitz/units/network"
+	"github.com/mjpitz/units/internal/quantitytest"
+	"github.com/mjpitz/units/unitstest"
 )
 
 func TestBandwidth(t *testing.T) {
@@ -47,7 +52,8 @@ func TestBandwidth(t *testing.T) {
 	require.Equal(t, "1Kibps", network.Kibibit.String())
 	require.Equal(t, "1bps", network.Bit.String())
 
-	require.Equal(t, "", network.Decimal.Format(0))
+	require.Equal(t, "0bps", network.Decimal.Format(0))
+	require.Equal(t, "0bps", network.Bandwidth(0).String())
 	require.Equal(t, "1Pbps", network.Decimal.Format(network.Petabit))
 	require.Equal(t, "1Tbps", network.Decimal.Format(network.Terabit))
 	require.Equal(t, "1Gbps", network.Decimal.Format(network.Gigabit))
@@ -67,6 +73,7 @@ func TestBandwidth(t *testing.T) {
 		{"+1Gibps", false, network.Gibibit},
 		{"10Gibps", false, 10 * network.Gibibit},
 		{"100DNE", true, 0},
+		{"5", true, 0},
 		{"BAD", true, 0},
 	}
 
@@ -82,3 +89,58 @@ func TestBandwidth(t *testing.T) {
 		require.Equal(t, testCase.expected, basic)
 	}
 }
+
+func TestUint64(t *testing.T) {
+	bandwidth, err := network.FromUint64(10_000_000_000)
+	require.NoError(t, err)
+	require.Equal(t, 10*network.Gigabit, bandwidth)
+
+	_, err = network.FromUint64(math.MaxUint64)
+	require.ErrorIs(t, err, units.ErrOverflow)
+
+	raw, err := network.Gibibit.Uint64()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1<<30), raw)
+
+	_, err = (-network.Bit).Uint64()
+	require.ErrorIs(t, err, units.ErrNegative)
+}
+
+func TestEncoding(t *testing.T) {
+	quantitytest.Encoding(t, 10*network.Gibibit, "10Gibps", "10 gibibits per second")
+}
+
+func TestSQL(t *testing.T) {
+	quantitytest.SQL(t, 10*network.Gibibit, "10Gibps", "10 gibibits per second")
+}
+
+func TestFormatter(t *testing.T) {
+	value := 1536 * network.Mebibit
+
+	testCases := []struct {
+		format   string
+		expected string
+	}{
+		{"%v", "1Gibps512Mibps"},
+		{"%.1v", "1.5Gibps"},
+		{"%+v", "1 gibibit per second 512 mebibits per second"},
+		{"%#v", "network.Mebibit*1536"},
+		{"%d", "1610612736"},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, fmt.Sprintf(testCase.format, value), testCase.format)
+	}
+
+	require.Equal(t, "network.Gigabit*3", fmt.Sprintf("%#v", 3*network.Gigabit))
+
+	var scanned network.Bandwidth
+	_, err := fmt.Sscanf("limit=1.5Gibps", "limit=%v", &scanned)
+	require.NoError(t, err)
+	require.Equal(t, value, scanned)
+}
+
+func TestUnits(t *testing.T) {
+	unitstest.Validate(t, network.Decimal, network.BinaryIEC)
+	unitstest.RoundTrip(t, network.Decimal, network.BinaryIEC)
+}
@@ -0,0 +1,640 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// index supports looking up the symbols of a Unit by their labels.
+type index struct {
+	// symbols maps every label to the position of its symbol
+	symbols map[string]int
+	// labels groups every label by its leading byte, ordered from longest to shortest
+	labels map[byte][]string
+	// fold indicates labels are stored in lower case and matched without regard to case
+	fold bool
+}
+
+// newIndex builds a lookup table from every label and name in the table to the position of its symbol. When labels are
+// repeated, the smallest symbol wins. Labels containing the micro sign (U+00B5) or Greek letter mu (U+03BC) are indexed
+// under both spellings since the two are visually indistinguishable. Symbols localized by the configured Locale are
+// indexed alongside their labels. When IgnoreCase is configured, labels are matched without regard to case and a
+// *CollisionError is returned if doing so would make labels of different sizes indistinguishable.
+func newIndex(t table, options Options) (*index, error) {
+	fold := options.IgnoreCase
+	if fold {
+		if err := collisions(t, options.Locale); err != nil {
+			return nil, err
+		}
+	}
+
+	idx := &index{
+		symbols: make(map[string]int),
+		labels:  make(map[byte][]string),
+		fold:    fold,
+	}
+
+	for i := t.count(); i > 0; i-- {
+		labels, names := t.symbol(i - 1)
+		for _, label := range spellings(labels, names, options.Locale) {
+			for _, variant := range variants(label) {
+				if fold {
+					variant = strings.ToLower(variant)
+				}
+
+				if _, ok := idx.symbols[variant]; !ok && variant != "" {
+					idx.labels[variant[0]] = append(idx.labels[variant[0]], variant)
+				}
+
+				idx.symbols[variant] = i - 1
+			}
+		}
+	}
+
+	for _, labels := range idx.labels {
+		sort.SliceStable(labels, func(i, j int) bool {
+			return len(labels[i]) > len(labels[j])
+		})
+	}
+
+	return idx, nil
+}
+
+// collisions returns a *CollisionError when labels of different sizes only differ by case.
+func collisions(t table, locale *Locale) error {
+	var (
+		keys    []string
+		groups  = make(map[string][]string)
+		symbols = make(map[string]int)
+		// ambiguous tracks folded labels that are shared by symbols of different sizes
+		ambiguous = make(map[string]bool)
+	)
+
+	for i := 0; i < t.count(); i++ {
+		labels, names := t.symbol(i)
+		for _, label := range spellings(labels, names, locale) {
+			folded := make(map[string]bool)
+			for _, variant := range variants(label) {
+				key := strings.ToLower(variant)
+				if folded[key] {
+					continue
+				}
+
+				folded[key] = true
+				if j, ok := symbols[key]; !ok {
+					keys = append(keys, key)
+					symbols[key] = i
+				} else if !t.same(i, j) {
+					ambiguous[key] = true
+				}
+
+				groups[key] = append(groups[key], label)
+			}
+		}
+	}
+
+	var collisions [][]string
+	for _, key := range keys {
+		if ambiguous[key] {
+			collisions = append(collisions, groups[key])
+		}
+	}
+
+	if len(collisions) > 0 {
+		return &CollisionError{collisions}
+	}
+
+	return nil
+}
+
+// match finds the longest label at the start of val[i:]. Labels must be followed by the end of the input, whitespace,
+// or the start of another measure to be considered a match. When parsing expressions, labels may also be followed by an
+// operator or parenthesis.
+func (idx *index) match(val string, i int, expr bool) (string, int, bool) {
+	if i >= len(val) {
+		return "", 0, false
+	}
+
+	lead := val[i]
+	if idx.fold {
+		lead = foldLead(val[i:])
+	}
+
+	for _, label := range idx.labels[lead] {
+		end := i + len(label)
+		if end > len(val) || !(val[i:end] == label || idx.fold && strings.EqualFold(val[i:end], label)) {
+			continue
+		}
+
+		if end == len(val) || val[end] == ' ' || val[end] == '\t' || isMeasure(val[end]) || isSign(val[end]) ||
+			expr && isOperator(val[end]) {
+			return val[i:end], idx.symbols[label], true
+		}
+	}
+
+	return "", 0, false
+}
+
+// foldLead returns the leading byte of val once its first character has been converted to lower case.
+func foldLead(val string) byte {
+	if c := val[0]; c < utf8.RuneSelf {
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+
+		return c
+	}
+
+	r, _ := utf8.DecodeRuneInString(val)
+
+	var buf [utf8.UTFMax]byte
+	utf8.EncodeRune(buf[:], unicode.ToLower(r))
+	return buf[0]
+}
+
+const (
+	microSign = "\u00b5"
+	greekMu   = "\u03bc"
+)
+
+// variants returns the spellings of a label that should be treated as equivalent.
+func variants(label string) []string {
+	switch {
+	case strings.Contains(label, microSign):
+		return []string{label, strings.ReplaceAll(label, microSign, greekMu)}
+	case strings.Contains(label, greekMu):
+		return []string{label, strings.ReplaceAll(label, greekMu, microSign)}
+	}
+
+	return []string{label}
+}
+
+// maxExponent bounds the exponents accepted by Parse to avoid unbounded arithmetic on inputs like "1e999999999B".
+const maxExponent = 1000
+
+var (
+	errDecimalPoints      = fmt.Errorf("%w: multiple decimal points", ErrInvalidNumber)
+	errMissingDigits      = fmt.Errorf("%w: missing digits", ErrInvalidNumber)
+	errExponent           = fmt.Errorf("%w: exponent out of range", ErrInvalidNumber)
+	errMisplacedSeparator = fmt.Errorf("%w: misplaced digit separator", ErrInvalidNumber)
+	errMixedSeparators    = fmt.Errorf("%w: digit separators cannot be mixed", ErrInvalidNumber)
+	errDigitGrouping      = fmt.Errorf("%w: thousands separators must group three digits", ErrInvalidNumber)
+)
+
+// scanner reads the components of a value, each of which is a measure followed by a symbol.
+type scanner struct {
+	idx    *index
+	locale *Locale
+	// input is the original text used to report errors
+	input string
+	// val holds the trimmed input without its sign, starting at offset within the input
+	val    string
+	offset int
+	neg    bool
+	i      int
+}
+
+// component is a single measure and symbol pair read by the scanner. The value of the component is the size of the
+// symbol multiplied by digits * 10^-scale.
+type component struct {
+	digits string
+	scale  int
+	symbol int
+	// measure holds the measure as written and text holds the measure along with its symbol, both starting at offset
+	measure, text string
+	at            int
+}
+
+// newScanner trims the provided value and reads its sign. Values that are empty or "0" have no components.
+func newScanner(idx *index, val string, options Options) (scanner, error) {
+	s := scanner{idx: idx, locale: options.Locale, input: val}
+	s.offset = len(val) - len(strings.TrimLeftFunc(val, unicode.IsSpace))
+
+	val = strings.TrimSpace(val)
+	if val == "" || val == "0" {
+		return s, nil
+	}
+
+	switch val[0] {
+	case '-':
+		s.neg = true
+		val = val[1:]
+		s.offset++
+	case '+':
+		val = val[1:]
+		s.offset++
+	}
+
+	if val == "" {
+		return s, &ParseError{s.input, s.offset, "", ErrValueDoesNotMatchPattern}
+	}
+
+	s.val = val
+	return s, nil
+}
+
+// more reports whether there are components left to read.
+func (s *scanner) more() bool {
+	return s.i < len(s.val)
+}
+
+// next reads the next component.
+func (s *scanner) next() (c component, err error) {
+	val, i := s.val, s.i
+
+	measure := i
+	i = s.locale.scan(val, i)
+
+	if measure == i {
+		return c, &ParseError{s.input, s.offset + i, nextToken(val[i:]), ErrValueDoesNotMatchPattern}
+	}
+
+	symbol := i
+	i = skipSpace(val, i)
+
+	label, pos, ok := s.idx.match(val, i, false)
+	if !ok {
+		label = nextToken(val[i:])
+		if label == "" || isMeasure(label[0]) || isSign(label[0]) {
+			return c, &ParseError{s.input, s.offset + symbol, "", ErrMissingSymbol}
+		}
+
+		return c, &ParseError{s.input, s.offset + i, label, ErrUnrecognizedSymbol}
+	}
+
+	i = skipSpace(val, i+len(label))
+	s.i = i
+
+	digits, scale, err := splitMeasure(s.locale.normalize(val[measure:symbol]))
+	if err != nil {
+		return c, &ParseError{s.input, s.offset + measure, val[measure:symbol], err}
+	}
+
+	return component{digits, scale, pos, val[measure:symbol], strings.TrimSpace(val[measure:i]), s.offset + measure}, nil
+}
+
+// fail reports a problem with the provided component. Inexact components only report their measure since the symbol
+// is not at fault.
+func (s *scanner) fail(c component, err error) error {
+	if err == ErrInexact {
+		return &ParseError{s.input, c.at, c.measure, err}
+	}
+
+	return &ParseError{s.input, c.at, c.text, err}
+}
+
+// parseBare parses a value holding only an integer in the base unit. See BareIntegers.
+func parseBare[T Number](val, digits string) (T, error) {
+	v, err := parseInteger[T](digits)
+	if err == nil {
+		return v, nil
+	}
+
+	// the digits were already validated, so ParseUint can only fail when they exceed the range of uint64
+	if _, ok := err.(*strconv.NumError); ok {
+		err = ErrOverflow
+	}
+
+	return 0, &ParseError{val, strings.Index(val, digits), digits, err}
+}
+
+func parse[T Number](u Unit[T], idx *index, val string, options Options) (size T, err error) {
+	if digits := strings.TrimSpace(val); options.BareIntegers && isInteger(digits) {
+		return parseBare[T](val, digits)
+	}
+
+	s, err := newScanner(idx, val, options)
+	if err != nil || !s.more() {
+		return 0, err
+	}
+
+	// negative values may reach one beyond the largest positive value of T
+	signed, limit := limits[T]()
+	switch {
+	case s.neg && !signed:
+		return 0, &ParseError{s.input, s.offset - 1, "-", ErrNegative}
+	case s.neg:
+		limit++
+	}
+
+	var (
+		mag uint64
+		// frac accumulates the components that are not whole numbers as exact fractions of the base unit
+		frac *big.Rat
+		// inexact tracks the last component contributing to frac so it can be reported in strict mode
+		inexact component
+		// last tracks the last component that was parsed so overflows can be reported
+		last component
+	)
+
+	for s.more() {
+		c, err := s.next()
+		if err != nil {
+			return 0, err
+		}
+
+		last = c
+		unit := uint64(u[c.symbol].Size)
+
+		// whole numbers are handled separately to avoid the cost of arbitrary precision arithmetic
+		if c.scale == 0 {
+			if whole, err := strconv.ParseUint(c.digits, 10, 64); err == nil {
+				hi, lo := bits.Mul64(whole, unit)
+				sum, carry := bits.Add64(mag, lo, 0)
+				if hi != 0 || carry != 0 || sum > limit {
+					return 0, s.fail(c, ErrOverflow)
+				}
+
+				mag = sum
+				continue
+			}
+		}
+
+		value := c.value(new(big.Int).SetUint64(unit))
+		if whole := new(big.Int).Quo(value.Num(), value.Denom()); !whole.IsUint64() || whole.Uint64() > limit {
+			return 0, s.fail(c, ErrOverflow)
+		}
+
+		if frac == nil {
+			frac = value
+		} else {
+			frac.Add(frac, value)
+		}
+
+		inexact = c
+	}
+
+	if frac != nil {
+		whole, rem := new(big.Int).QuoRem(frac.Num(), frac.Denom(), new(big.Int))
+		if !whole.IsUint64() {
+			return 0, s.fail(last, ErrOverflow)
+		}
+
+		sum, carry := bits.Add64(mag, whole.Uint64(), 0)
+		if carry != 0 || sum > limit {
+			return 0, s.fail(last, ErrOverflow)
+		}
+
+		mag = sum
+
+		if rem.Sign() != 0 {
+			if options.Strict {
+				return 0, s.fail(inexact, ErrInexact)
+			}
+
+			if roundUp(s.neg, mag%2 == 1, rem, frac.Denom(), options.Rounding) {
+				if mag == limit {
+					return 0, s.fail(last, ErrOverflow)
+				}
+
+				mag++
+			}
+		}
+	}
+
+	size, ok := fromMagnitude[T](s.neg, mag)
+	if !ok {
+		return 0, s.fail(last, ErrOverflow)
+	}
+
+	return size, nil
+}
+
+// value returns the exact value of the component given the size of its symbol.
+func (c component) value(size *big.Int) *big.Rat {
+	num, _ := new(big.Int).SetString(c.digits, 10)
+	return new(big.Rat).SetFrac(num.Mul(num, size), pow10(c.scale))
+}
+
+// scanMeasure returns the end of the measure starting at i. Measures start with a digit or decimal point and may
+// contain digit separators ('_' or ','). An exponent is included when an 'e' or 'E' is followed by an optionally signed
+// digit, otherwise the letter is left to be read as part of the symbol.
+func scanMeasure(val string, i int) int {
+	if i >= len(val) || !isMeasure(val[i]) {
+		return i
+	}
+
+	for i < len(val) && (isMeasure(val[i]) || isSeparator(val[i])) {
+		i++
+	}
+
+	return scanExponent(val, i)
+}
+
+// scanExponent returns the end of the exponent starting at i, or i when there is none. An exponent is an 'e' or 'E'
+// followed by an optionally signed digit.
+func scanExponent(val string, i int) int {
+	if i < len(val) && (val[i] == 'e' || val[i] == 'E') {
+		j := i + 1
+		if j < len(val) && isSign(val[j]) {
+			j++
+		}
+
+		if j < len(val) && isDigit(val[j]) {
+			for i = j; i < len(val) && (isDigit(val[i]) || val[i] == '_'); i++ {
+			}
+		}
+	}
+
+	return i
+}
+
+// splitMeasure converts a measure into its digits and scale such that the value of the measure is digits * 10^-scale.
+// Digit separators are removed and exponents are folded into the scale.
+func splitMeasure(measure string) (digits string, scale int, err error) {
+	mantissa, exponent := measure, ""
+	if i := strings.IndexAny(measure, "eE"); i >= 0 {
+		mantissa, exponent = measure[:i], measure[i+1:]
+	}
+
+	whole, fraction, _ := strings.Cut(mantissa, ".")
+	if strings.Contains(fraction, ".") {
+		return "", 0, errDecimalPoints
+	}
+
+	if whole, err = stripSeparators(whole, true); err != nil {
+		return "", 0, err
+	}
+
+	if fraction, err = stripSeparators(fraction, false); err != nil {
+		return "", 0, err
+	}
+
+	if whole+fraction == "" {
+		return "", 0, errMissingDigits
+	}
+
+	exp := 0
+	if exponent != "" {
+		neg := exponent[0] == '-'
+		if exponent, err = stripSeparators(strings.TrimLeft(exponent, "+-"), false); err != nil {
+			return "", 0, err
+		}
+
+		if exp, err = strconv.Atoi(exponent); err != nil || exp > maxExponent {
+			return "", 0, errExponent
+		}
+
+		if neg {
+			exp = -exp
+		}
+	}
+
+	digits, scale = whole+fraction, len(fraction)-exp
+	if scale < 0 {
+		digits, scale = digits+strings.Repeat("0", -scale), 0
+	}
+
+	return digits, scale, nil
+}
+
+// stripSeparators removes the digit separators from a run of digits. Separators must appear between two digits and
+// cannot be mixed. When grouping is allowed, ',' may be used as a thousands separator, in which case every group
+// following the first must contain exactly three digits.
+func stripSeparators(digits string, grouping bool) (string, error) {
+	if strings.IndexFunc(digits, func(r rune) bool { return r == '_' || r == ',' }) < 0 {
+		return digits, nil
+	}
+
+	var (
+		stripped  = make([]byte, 0, len(digits))
+		separator byte
+		group     = 0
+	)
+
+	for i := 0; i < len(digits); i++ {
+		c := digits[i]
+		if isDigit(c) {
+			stripped = append(stripped, c)
+			group++
+			continue
+		}
+
+		switch {
+		case c == ',' && !grouping:
+			return "", errMisplacedSeparator
+		case i == 0 || i == len(digits)-1 || !isDigit(digits[i-1]) || !isDigit(digits[i+1]):
+			return "", errMisplacedSeparator
+		case separator != 0 && separator != c:
+			return "", errMixedSeparators
+		case c == ',' && (group > 3 || (separator == ',' && group != 3)):
+			return "", errDigitGrouping
+		}
+
+		separator, group = c, 0
+	}
+
+	if separator == ',' && group != 3 {
+		return "", errDigitGrouping
+	}
+
+	return string(stripped), nil
+}
+
+// pow10 returns 10 raised to the provided power.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// roundUp determines if the magnitude of a value should be incremented given whether it is odd and the remainder of a
+// division by den.
+func roundUp(neg, odd bool, rem, den *big.Int, mode RoundingMode) bool {
+	switch mode {
+	case RoundFloor:
+		return neg
+	case RoundCeil:
+		return !neg
+	case RoundHalfEven:
+		switch new(big.Int).Lsh(rem, 1).Cmp(den) {
+		case 1:
+			return true
+		case 0:
+			return odd
+		}
+	}
+
+	return false
+}
+
+// parsable reports whether the provided label can be matched by the scanner used in Parse. Labels may not be empty,
+// may not have surrounding whitespace (which is trimmed from the input), and may not start with characters that would
+// be read as part of the preceding measure.
+func parsable(label string) bool {
+	if label == "" || label != strings.TrimSpace(label) {
+		return false
+	}
+
+	return scanMeasure("0"+label, 0) == 1
+}
+
+func skipSpace(val string, i int) int {
+	for i < len(val) && (val[i] == ' ' || val[i] == '\t') {
+		i++
+	}
+
+	return i
+}
+
+func isMeasure(c byte) bool {
+	return isDigit(c) || c == '.'
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isSeparator(c byte) bool {
+	return c == '_' || c == ','
+}
+
+func isSign(c byte) bool {
+	return c == '+' || c == '-'
+}
+
+// isOperator reports whether c is an operator or parenthesis that may follow a label in an expression. Signs double as
+// operators and are handled by isSign.
+func isOperator(c byte) bool {
+	return c == '*' || c == '/' || c == '(' || c == ')'
+}
+
+// nextToken returns the leading token of val for use in error messages. Tokens are either a run of characters that
+// cannot start a measure and aren't operators (for example, "MB" in "MB*2"), or a single character otherwise.
+func nextToken(val string) string {
+	i := 0
+	for i < len(val) && !isMeasure(val[i]) && !isSign(val[i]) && !isOperator(val[i]) {
+		i++
+	}
+
+	if i == 0 && len(val) > 0 {
+		_, size := utf8.DecodeRuneInString(val)
+		i = size
+	}
+
+	return strings.TrimSpace(val[:i])
+}
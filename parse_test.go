@@ -0,0 +1,398 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mjpitz/units"
+)
+
+var metric = units.Unit[int64]{
+	{1, []string{"nm"}, nil},
+	{1000, []string{"um"}, nil},
+	{1000000, []string{"mm"}, nil},
+	{1000000000, []string{"m"}, nil},
+	{1000000000000000, []string{"Pm"}, nil},
+}
+
+func TestParse_Exact(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected int64
+	}{
+		{"9.007199254740993Pm", 9007199254740993},
+		{"9007199254740993nm", 9007199254740993},
+		{"0.1um", 100},
+		{"0.001um", 1},
+		{"1.000000001m", 1000000001},
+		{"1.0m", 1000000000},
+		{"1.m", 1000000000},
+		{".5um", 500},
+		{"0.5nm0.5nm", 1},
+		{"-0.000001mm", -1},
+		{"9223372036854775807nm", 9223372036854775807},
+		{"-9223372036854775808nm", -9223372036854775808},
+		{"00000000000000000000000000001nm", 1},
+	}
+
+	for _, testCase := range testCases {
+		parsed, err := metric.Parse(testCase.input, units.Strict())
+		require.NoError(t, err, testCase.input)
+		require.Equal(t, testCase.expected, parsed, testCase.input)
+	}
+
+	parsed, err := metric.Parse("9223372036.8547758071m")
+	require.NoError(t, err)
+	require.Equal(t, int64(9223372036854775807), parsed)
+
+	for _, input := range []string{".nm", "1.2.3nm", "..nm"} {
+		_, err := metric.Parse(input)
+		require.ErrorIs(t, err, units.ErrInvalidNumber, input)
+	}
+}
+
+func TestParse_Rounding(t *testing.T) {
+	testCases := []struct {
+		input                           string
+		truncate, floor, ceil, halfEven int64
+	}{
+		{"0.5nm", 0, 0, 1, 0},
+		{"1.5nm", 1, 1, 2, 2},
+		{"2.5nm", 2, 2, 3, 2},
+		{"2.51nm", 2, 2, 3, 3},
+		{"2.49nm", 2, 2, 3, 2},
+		{"-0.5nm", 0, -1, 0, 0},
+		{"-1.5nm", -1, -2, -1, -2},
+		{"-2.5nm", -2, -3, -2, -2},
+		{"-2.51nm", -2, -3, -2, -3},
+		{"1um0.25nm", 1000, 1000, 1001, 1000},
+		{"3nm", 3, 3, 3, 3},
+	}
+
+	for _, testCase := range testCases {
+		for mode, expected := range map[units.RoundingMode]int64{
+			units.RoundTruncate: testCase.truncate,
+			units.RoundFloor:    testCase.floor,
+			units.RoundCeil:     testCase.ceil,
+			units.RoundHalfEven: testCase.halfEven,
+		} {
+			parsed, err := metric.Parse(testCase.input, units.Rounding(mode))
+			require.NoError(t, err, testCase.input)
+			require.Equal(t, expected, parsed, "%s with mode %d", testCase.input, mode)
+		}
+
+		parsed, err := metric.Parse(testCase.input)
+		require.NoError(t, err, testCase.input)
+		require.Equal(t, testCase.halfEven, parsed, testCase.input)
+	}
+}
+
+func TestParse_Strict(t *testing.T) {
+	_, err := metric.Parse("1um 0.5nm", units.Strict())
+	require.ErrorIs(t, err, units.ErrInexact)
+	require.EqualError(t, err, `value cannot be represented exactly "0.5" at offset 4 of "1um 0.5nm"`)
+
+	codec := units.MustCompile(metric)
+	_, err = codec.Parse("0.0001um", units.Strict(), units.Rounding(units.RoundCeil))
+	require.ErrorIs(t, err, units.ErrInexact)
+
+	parsed, err := codec.Parse("0.0001um", units.Rounding(units.RoundCeil))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), parsed)
+}
+
+func TestParse_Overflow(t *testing.T) {
+	testCases := []struct {
+		input  string
+		offset int
+		token  string
+	}{
+		{"9223372036854775808nm", 0, "9223372036854775808nm"},
+		{"18446744073709551616nm", 0, "18446744073709551616nm"},
+		{"10000Pm", 0, "10000Pm"},
+		{"9000Pm 1000Pm", 7, "1000Pm"},
+		{"9223372036854775807nm1nm", 21, "1nm"},
+		{"9223372036.8547758075m", 0, "9223372036.8547758075m"},
+		{"9223372036.8547758071m 0.5nm", 23, "0.5nm"},
+		{"-9223372036854775809nm", 1, "9223372036854775809nm"},
+	}
+
+	for _, testCase := range testCases {
+		_, err := metric.Parse(testCase.input, units.Rounding(units.RoundCeil))
+		require.ErrorIs(t, err, units.ErrOverflow, testCase.input)
+
+		var parseErr *units.ParseError
+		require.ErrorAs(t, err, &parseErr)
+		require.Equal(t, testCase.offset, parseErr.Offset, testCase.input)
+		require.Equal(t, testCase.token, parseErr.Token, testCase.input)
+	}
+
+	parsed, err := metric.Parse("-9223372036.854775808m")
+	require.NoError(t, err)
+	require.Equal(t, int64(-9223372036854775808), parsed)
+
+	_, err = metric.Parse("-9223372036.8547758081m", units.Rounding(units.RoundFloor))
+	require.ErrorIs(t, err, units.ErrOverflow)
+
+	small := units.Unit[int8]{{1, []string{"B"}, nil}, {16, []string{"X"}, nil}}
+	_, err = small.Parse("8X")
+	require.ErrorIs(t, err, units.ErrOverflow)
+
+	parsed8, err := small.Parse("-8X")
+	require.NoError(t, err)
+	require.Equal(t, int8(-128), parsed8)
+}
+
+func TestParse_Numbers(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected int64
+	}{
+		{"1.5e3nm", 1500},
+		{"1.5E3nm", 1500},
+		{"1.5e+3nm", 1500},
+		{"15e-1um", 1500},
+		{"1e0m", 1000000000},
+		{".5e1nm", 5},
+		{"1_000_000nm", 1000000},
+		{"1_0.0_1um", 10010},
+		{"1e1_0nm", 10000000000},
+		{"1,024um", 1024000},
+		{"1,000,000.5um", 1000000500},
+		{"999,999nm", 999999},
+		{".5um", 500},
+		{"0.5e-3mm 1e3nm", 1500},
+	}
+
+	for _, testCase := range testCases {
+		parsed, err := metric.Parse(testCase.input, units.Strict())
+		require.NoError(t, err, testCase.input)
+		require.Equal(t, testCase.expected, parsed, testCase.input)
+	}
+
+	invalid := []struct {
+		input string
+		token string
+		msg   string
+	}{
+		{"1.2.3nm", "1.2.3", "multiple decimal points"},
+		{"1__000nm", "1__000", "misplaced digit separator"},
+		{"_1nm", "_", ""},
+		{"1_nm", "1_", "misplaced digit separator"},
+		{"1_.5nm", "1_.5", "misplaced digit separator"},
+		{"1.5,0nm", "1.5,0", "misplaced digit separator"},
+		{"1,0_00nm", "1,0_00", "digit separators cannot be mixed"},
+		{"1,5nm", "1,5", "thousands separators must group three digits"},
+		{"1000,000nm", "1000,000", "thousands separators must group three digits"},
+		{"1,00,000nm", "1,00,000", "thousands separators must group three digits"},
+		{"1e99999nm", "1e99999", "exponent out of range"},
+		{"1e999999999999999999999nm", "1e999999999999999999999", "exponent out of range"},
+		{".e1nm", ".e1", "missing digits"},
+	}
+
+	for _, testCase := range invalid {
+		_, err := metric.Parse(testCase.input)
+		require.Error(t, err, testCase.input)
+
+		var parseErr *units.ParseError
+		require.ErrorAs(t, err, &parseErr)
+		require.Equal(t, testCase.token, parseErr.Token, testCase.input)
+
+		if testCase.msg != "" {
+			require.ErrorIs(t, err, units.ErrInvalidNumber, testCase.input)
+			require.Contains(t, err.Error(), testCase.msg, testCase.input)
+		}
+	}
+
+	// exponents require digits, otherwise the letter belongs to the symbol
+	exa := units.Unit[int64]{{1, []string{"B"}, nil}, {1000000000000000000, []string{"EB"}, nil}}
+	parsed, err := exa.Parse("1EB")
+	require.NoError(t, err)
+	require.Equal(t, int64(1000000000000000000), parsed)
+
+	parsed, err = exa.Parse("1E1B")
+	require.NoError(t, err)
+	require.Equal(t, int64(10), parsed)
+
+	_, err = metric.Parse("1e-1001nm")
+	require.ErrorIs(t, err, units.ErrInvalidNumber)
+
+	parsed, err = metric.Parse("0e1000nm")
+	require.NoError(t, err)
+	require.Equal(t, int64(0), parsed)
+
+	_, err = metric.Parse("1e1000nm")
+	require.ErrorIs(t, err, units.ErrOverflow)
+}
+
+func TestParse_Symbols(t *testing.T) {
+	unit := units.Unit[int64]{
+		{1, []string{"m", "\u00b5s"}, nil},
+		{10, []string{"mm"}, nil},
+		{100, []string{"fl. oz", "fluid ounce"}, nil},
+		{1000, []string{"mi", "m2", "-x"}, nil},
+	}
+
+	require.NoError(t, unit.Validate())
+
+	testCases := []struct {
+		input    string
+		expected int64
+	}{
+		{"1m", 1},
+		{"1mm", 10},
+		{"1mi", 1000},
+		{"1m2", 1000},
+		{"1m2 3m", 1003},
+		{"1mm1m", 11},
+		{"2fl. oz", 200},
+		{"2 fluid ounce 1 m", 201},
+		{"1-x", 1000},
+		{"1\u00b5s", 1},
+		{"1\u03bcs", 1},
+	}
+
+	for _, testCase := range testCases {
+		parsed, err := unit.Parse(testCase.input)
+		require.NoError(t, err, testCase.input)
+		require.Equal(t, testCase.expected, parsed, testCase.input)
+	}
+
+	_, err := unit.Parse("1mx")
+	require.ErrorIs(t, err, units.ErrUnrecognizedSymbol)
+	require.EqualError(t, err, `unrecognized symbol "mx" at offset 1 of "1mx"`)
+
+	_, err = unit.Parse("1fluid")
+	require.ErrorIs(t, err, units.ErrUnrecognizedSymbol)
+}
+
+func TestParse_IgnoreCase(t *testing.T) {
+	for input, expected := range map[string]int64{
+		"512mib":             512 << 20,
+		"1GIB512mIb":         1<<30 + 512<<20,
+		"2 Gibibytes":        2 << 30,
+		"1 KIBIBYTE 1 BYTES": 1<<10 + 1,
+	} {
+		parsed, err := binary.Parse(input, units.IgnoreCase())
+		require.NoError(t, err, input)
+		require.Equal(t, expected, parsed, input)
+	}
+
+	_, err := binary.Parse("512mib")
+	require.ErrorIs(t, err, units.ErrUnrecognizedSymbol)
+
+	_, err = binary.Parse("512mibs", units.IgnoreCase())
+	require.ErrorIs(t, err, units.ErrUnrecognizedSymbol)
+	require.EqualError(t, err, `unrecognized symbol "mibs" at offset 3 of "512mibs"`)
+
+	greek := units.Unit[int64]{
+		{1, []string{"µs"}, nil},
+		{1000, []string{"ms"}, nil},
+	}
+
+	for _, input := range []string{"1µs", "1μs", "1Μs", "1µS"} {
+		parsed, err := greek.Parse(input, units.IgnoreCase())
+		require.NoError(t, err, input)
+		require.Equal(t, int64(1), parsed, input)
+	}
+}
+
+func TestParse_IgnoreCaseCollisions(t *testing.T) {
+	testCases := []struct {
+		unit       units.Unit[int64]
+		collisions [][]string
+	}{
+		{
+			units.Unit[int64]{{1, []string{"mB"}, nil}, {1000, []string{"MB"}, nil}},
+			[][]string{{"mB", "MB"}},
+		},
+		{
+			units.Unit[int64]{{1, []string{"mbps"}, nil}, {1000, []string{"kbps"}, nil}, {1000000, []string{"Mbps"}, nil}},
+			[][]string{{"mbps", "Mbps"}},
+		},
+		{
+			units.Unit[int64]{
+				{1, []string{"m"}, []string{"meter"}},
+				{1000, []string{"km", "K"}, nil},
+				{1000000, []string{"M", "k"}, []string{"Meter"}},
+			},
+			[][]string{{"m", "M"}, {"meter", "Meter"}, {"meters", "Meters"}, {"K", "k"}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		_, err := testCase.unit.Parse("1m", units.IgnoreCase())
+		require.ErrorIs(t, err, units.ErrAmbiguousLabel)
+
+		var cerr *units.CollisionError
+		require.ErrorAs(t, err, &cerr)
+		require.Equal(t, testCase.collisions, cerr.Collisions)
+	}
+
+	unit := units.Unit[int64]{{1, []string{"b", "B"}, nil}, {1000, []string{"kB"}, nil}}
+	parsed, err := unit.Parse("1KB 1b", units.IgnoreCase())
+	require.NoError(t, err)
+	require.Equal(t, int64(1001), parsed)
+
+	_, err = units.Unit[int64]{{1, []string{"mB"}, nil}, {1000, []string{"MB"}, nil}}.Parse("1MB", units.IgnoreCase())
+	require.EqualError(t, err, `labels collide when case is ignored: "mB", "MB"`)
+}
+
+func TestParse_BareIntegers(t *testing.T) {
+	for input, expected := range map[string]int64{
+		"512":                  512,
+		" -1024 ":              -1024,
+		"+1":                   1,
+		"0":                    0,
+		"9223372036854775807":  1<<63 - 1,
+		"-9223372036854775808": -1 << 63,
+	} {
+		parsed, err := metric.Parse(input, units.BareIntegers())
+		require.NoError(t, err, input)
+		require.Equal(t, expected, parsed, input)
+
+		parsed, err = units.MustCompile(metric, units.BareIntegers()).Parse(input)
+		require.NoError(t, err, input)
+		require.Equal(t, expected, parsed, input)
+	}
+
+	_, err := metric.Parse("512")
+	require.ErrorIs(t, err, units.ErrMissingSymbol)
+
+	for _, input := range []string{"1um 512", "1.5", "+-1", "1e3", "1_000"} {
+		_, err = metric.Parse(input, units.BareIntegers())
+		require.Error(t, err, input)
+	}
+
+	_, err = metric.Parse(" 9223372036854775808", units.BareIntegers())
+	require.ErrorIs(t, err, units.ErrOverflow)
+	require.EqualError(t, err, `value overflows type "9223372036854775808" at offset 1 of " 9223372036854775808"`)
+
+	_, err = metric.Parse("99999999999999999999", units.BareIntegers())
+	require.ErrorIs(t, err, units.ErrOverflow)
+
+	small := units.Unit[uint8]{{1, []string{"B"}, nil}}
+	_, err = small.Parse("-1", units.BareIntegers())
+	require.ErrorIs(t, err, units.ErrNegative)
+}
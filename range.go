@@ -0,0 +1,352 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrEmptyRange notifies the caller that a range does not contain any values (for example, "20GiB-10GiB").
+var ErrEmptyRange = fmt.Errorf("range does not contain any values")
+
+// Range describes an interval of values. Each side of the range is optional, allowing bounds such as "<=5MB" to be
+// expressed alongside intervals such as "10GiB-20GiB". The zero value is unbounded and contains every value.
+//
+// Range implements the same Set and String methods as the quantity types, allowing a flag to carry a validated
+// interval. When used this way, T must implement Set (for example, data.Size). Otherwise, see ParseRange and Format.
+type Range[T Number] struct {
+	// Min and Max hold the bounds of the range and are only used when HasMin or HasMax is set.
+	Min, Max       T
+	HasMin, HasMax bool
+	// ExclusiveMin and ExclusiveMax exclude the bound from the range (for example, ">1km").
+	ExclusiveMin, ExclusiveMax bool
+}
+
+// ParseRange parses the provided range using the Unit to parse each bound. Ranges are written as an interval (for
+// example, "10GiB-20GiB" or "1GiB..4GiB"), a comparison (for example, "<=5MB" or ">1km"), a pair of comparisons
+// separated by whitespace (for example, ">1GiB <=4GiB"), or a single value. Either side of a ".." interval may be
+// omitted to leave it unbounded. An empty string produces an unbounded range. ErrEmptyRange is returned when the range
+// does not contain any values.
+func ParseRange[T Number](u Unit[T], val string, opts ...Option) (Range[T], error) {
+	options := apply(opts)
+
+	idx, err := newIndex(u, options)
+	if err != nil {
+		return Range[T]{}, err
+	}
+
+	return parseRange(val, func(s string) (T, error) {
+		return parse(u, idx, s, options)
+	})
+}
+
+// parseRange parses the provided range using the provided function to parse each bound.
+func parseRange[T Number](val string, parse func(string) (T, error)) (r Range[T], err error) {
+	offset := len(val) - len(strings.TrimLeftFunc(val, unicode.IsSpace))
+	s := strings.TrimSpace(val)
+
+	// bound parses the value found at position i of s, reporting errors relative to the original input
+	bound := func(i, j int) (T, error) {
+		text := strings.TrimRightFunc(s[i:j], unicode.IsSpace)
+		skip := len(text) - len(strings.TrimLeftFunc(text, unicode.IsSpace))
+		if text = text[skip:]; text == "" {
+			return 0, &ParseError{val, offset + j, "", ErrValueDoesNotMatchPattern}
+		}
+
+		return parseAt(val, offset+i+skip, text, parse)
+	}
+
+	switch {
+	case s == "":
+		return r, nil
+	case s[0] == '<' || s[0] == '>':
+		// a second comparison starts at the next operator since neither may appear within a value
+		split := len(s)
+		if i := strings.IndexAny(s[1:], "<>"); i >= 0 {
+			split = i + 1
+		}
+
+		if err = r.compare(s, 0, split, bound); err != nil {
+			return r, err
+		}
+
+		if split < len(s) {
+			// each side of the range may only be bounded once
+			if s[split] == s[0] {
+				return r, &ParseError{val, offset + split, s[split:], ErrValueDoesNotMatchPattern}
+			}
+
+			if err = r.compare(s, split, len(s), bound); err != nil {
+				return r, err
+			}
+		}
+	case strings.Contains(s, ".."):
+		i := strings.Index(s, "..")
+		if strings.TrimSpace(s[:i]) == "" && strings.TrimSpace(s[i+2:]) == "" {
+			return r, &ParseError{val, offset, s, ErrValueDoesNotMatchPattern}
+		}
+
+		if strings.TrimSpace(s[:i]) != "" {
+			if r.Min, err = bound(0, i); err != nil {
+				return r, err
+			}
+
+			r.HasMin = true
+		}
+
+		if strings.TrimSpace(s[i+2:]) != "" {
+			if r.Max, err = bound(i+2, len(s)); err != nil {
+				return r, err
+			}
+
+			r.HasMax = true
+		}
+	default:
+		value, err := bound(0, len(s))
+		if err != nil {
+			// values may be separated by a hyphen, though it may also be the sign of the second value (for
+			// example, "-2GiB--1GiB")
+			for i := 1; i < len(s); i++ {
+				if s[i] != '-' {
+					continue
+				}
+
+				lower, lowerErr := bound(0, i)
+				upper, upperErr := bound(i+1, len(s))
+				if lowerErr == nil && upperErr == nil {
+					value, err = lower, nil
+					r.Max, r.HasMax = upper, true
+					break
+				}
+			}
+
+			if err != nil {
+				return r, err
+			}
+		}
+
+		r.Min, r.HasMin = value, true
+		if !r.HasMax {
+			r.Max, r.HasMax = value, true
+		}
+	}
+
+	if r.Empty() {
+		return r, &ParseError{val, offset, s, ErrEmptyRange}
+	}
+
+	return r, nil
+}
+
+// compare parses the comparison held by s[i:j], setting the corresponding bound of the range.
+func (r *Range[T]) compare(s string, i, j int, bound func(i, j int) (T, error)) (err error) {
+	op := 1
+	if j > i+1 && s[i+1] == '=' {
+		op = 2
+	}
+
+	exclusive := op == 1
+	if s[i] == '>' {
+		r.Min, err = bound(i+op, j)
+		r.HasMin, r.ExclusiveMin = true, exclusive
+	} else {
+		r.Max, err = bound(i+op, j)
+		r.HasMax, r.ExclusiveMax = true, exclusive
+	}
+
+	return err
+}
+
+// Format renders the range using the Unit to format each bound. Intervals are rendered as "min..max", bounds as
+// comparisons (for example, ">=1GiB" or ">1GiB <=4GiB"), and ranges containing a single value as that value. Unbounded
+// ranges are rendered as an empty string.
+func (r Range[T]) Format(u Unit[T], opts ...Option) string {
+	options := apply(opts)
+
+	return r.format(func(value T) string {
+		return u.format(value, options)
+	})
+}
+
+// format renders the range using the provided function to format each bound.
+func (r Range[T]) format(format func(T) string) string {
+	if r.HasMin && r.HasMax && !r.ExclusiveMin && !r.ExclusiveMax {
+		if r.Min == r.Max {
+			return format(r.Min)
+		}
+
+		return format(r.Min) + ".." + format(r.Max)
+	}
+
+	var str string
+	if r.HasMin {
+		str = ">=" + format(r.Min)
+		if r.ExclusiveMin {
+			str = ">" + format(r.Min)
+		}
+	}
+
+	if r.HasMax {
+		if str != "" {
+			str += " "
+		}
+
+		if r.ExclusiveMax {
+			str += "<" + format(r.Max)
+		} else {
+			str += "<=" + format(r.Max)
+		}
+	}
+
+	return str
+}
+
+// Set parses the provided range using the Set method of T, allowing ranges to be used as flags. See ParseRange for the
+// accepted syntax.
+func (r *Range[T]) Set(val string) error {
+	v, err := parseRange(val, set[T])
+	if err != nil {
+		return err
+	}
+
+	*r = v
+	return nil
+}
+
+// String renders the range using the default format of T (for example, the String method of data.Size). See Format.
+func (r Range[T]) String() string {
+	return r.format(func(value T) string {
+		return fmt.Sprint(value)
+	})
+}
+
+// parseAt parses the text found at the provided offset of the input, reporting errors relative to the input.
+func parseAt[T Number](input string, at int, text string, parse func(string) (T, error)) (T, error) {
+	value, err := parse(text)
+
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		return 0, &ParseError{input, at + parseErr.Offset, parseErr.Token, parseErr.Err}
+	}
+
+	return value, err
+}
+
+// set parses the provided value using the Set method of T, which quantity types implement to support flags.
+func set[T Number](val string) (value T, err error) {
+	setter, ok := interface{}(&value).(interface{ Set(string) error })
+	if !ok {
+		return 0, fmt.Errorf("%T does not implement Set", value)
+	}
+
+	err = setter.Set(val)
+	return value, err
+}
+
+// Contains reports whether the value falls within the range.
+func (r Range[T]) Contains(value T) bool {
+	lo, hi, ok := r.bounds()
+	return ok && lo <= value && value <= hi
+}
+
+// Clamp returns the value within the range that is nearest to the provided value. Values below an exclusive minimum are
+// clamped to the next representable value (for example, ">1km" clamps 0 to 1km plus one base unit). Empty ranges return
+// the value unchanged.
+func (r Range[T]) Clamp(value T) T {
+	lo, hi, ok := r.bounds()
+	switch {
+	case !ok:
+		return value
+	case value < lo:
+		return lo
+	case value > hi:
+		return hi
+	}
+
+	return value
+}
+
+// Intersect returns the range of values contained by both ranges, reporting false when they do not overlap.
+func (r Range[T]) Intersect(other Range[T]) (Range[T], bool) {
+	result := r
+
+	switch {
+	case !other.HasMin:
+	case !result.HasMin || other.Min > result.Min:
+		result.Min, result.HasMin, result.ExclusiveMin = other.Min, true, other.ExclusiveMin
+	case other.Min == result.Min:
+		result.ExclusiveMin = result.ExclusiveMin || other.ExclusiveMin
+	}
+
+	switch {
+	case !other.HasMax:
+	case !result.HasMax || other.Max < result.Max:
+		result.Max, result.HasMax, result.ExclusiveMax = other.Max, true, other.ExclusiveMax
+	case other.Max == result.Max:
+		result.ExclusiveMax = result.ExclusiveMax || other.ExclusiveMax
+	}
+
+	return result, !result.Empty()
+}
+
+// Empty reports whether the range does not contain any values.
+func (r Range[T]) Empty() bool {
+	_, _, ok := r.bounds()
+	return !ok
+}
+
+// bounds returns the smallest and largest values contained by the range, reporting false when it is empty.
+func (r Range[T]) bounds() (lo, hi T, ok bool) {
+	signed, max := limits[T]()
+
+	var smallest, largest T = 0, T(max)
+	if signed {
+		smallest = -largest - 1
+	}
+
+	lo, hi = smallest, largest
+	if r.HasMin {
+		lo = r.Min
+		if r.ExclusiveMin {
+			if lo == largest {
+				return lo, hi, false
+			}
+
+			lo++
+		}
+	}
+
+	if r.HasMax {
+		hi = r.Max
+		if r.ExclusiveMax {
+			if hi == smallest {
+				return lo, hi, false
+			}
+
+			hi--
+		}
+	}
+
+	return lo, hi, lo <= hi
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mjpitz/units"
+)
+
+func TestParseRange(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected units.Range[int64]
+		str      string
+	}{
+		{"", units.Range[int64]{}, ""},
+		{"10GiB-20GiB", units.Range[int64]{Min: 10 << 30, Max: 20 << 30, HasMin: true, HasMax: true}, "10GiB..20GiB"},
+		{" 10GiB - 20GiB ", units.Range[int64]{Min: 10 << 30, Max: 20 << 30, HasMin: true, HasMax: true}, "10GiB..20GiB"},
+		{"-2GiB--1GiB", units.Range[int64]{Min: -2 << 30, Max: -1 << 30, HasMin: true, HasMax: true}, "-2GiB..-1GiB"},
+		{"1GiB..4GiB", units.Range[int64]{Min: 1 << 30, Max: 4 << 30, HasMin: true, HasMax: true}, "1GiB..4GiB"},
+		{"1GiB..", units.Range[int64]{Min: 1 << 30, HasMin: true}, ">=1GiB"},
+		{"..4GiB", units.Range[int64]{Max: 4 << 30, HasMax: true}, "<=4GiB"},
+		{"<=5MiB", units.Range[int64]{Max: 5 << 20, HasMax: true}, "<=5MiB"},
+		{"<5MiB", units.Range[int64]{Max: 5 << 20, HasMax: true, ExclusiveMax: true}, "<5MiB"},
+		{">1KiB", units.Range[int64]{Min: 1 << 10, HasMin: true, ExclusiveMin: true}, ">1KiB"},
+		{">= 1 KiB", units.Range[int64]{Min: 1 << 10, HasMin: true}, ">=1KiB"},
+		{">1GiB <=4GiB", units.Range[int64]{Min: 1 << 30, Max: 4 << 30, HasMin: true, HasMax: true, ExclusiveMin: true}, ">1GiB <=4GiB"},
+		{"<4GiB >=1GiB", units.Range[int64]{Min: 1 << 30, Max: 4 << 30, HasMin: true, HasMax: true, ExclusiveMax: true}, ">=1GiB <4GiB"},
+		{"1.5GiB", units.Range[int64]{Min: 3 << 29, Max: 3 << 29, HasMin: true, HasMax: true}, "1GiB512MiB"},
+	}
+
+	for _, testCase := range testCases {
+		r, err := units.ParseRange(binary, testCase.input)
+		require.NoError(t, err, testCase.input)
+		require.Equal(t, testCase.expected, r, testCase.input)
+		require.Equal(t, testCase.str, r.Format(binary), testCase.input)
+
+		parsed, err := units.ParseRange(binary, r.Format(binary))
+		require.NoError(t, err, testCase.input)
+		require.Equal(t, r, parsed, testCase.input)
+	}
+
+	r := units.Range[int64]{Min: 3 << 29, Max: 2 << 30, HasMin: true, HasMax: true}
+	require.Equal(t, "1.5 gibibytes..2 gibibytes", r.Format(binary, units.Verbose(), units.Largest()))
+}
+
+func TestParseRange_Errors(t *testing.T) {
+	testCases := []struct {
+		input  string
+		offset int
+		token  string
+		cause  error
+	}{
+		{"20GiB-10GiB", 0, "20GiB-10GiB", units.ErrEmptyRange},
+		{"<1GiB >1GiB", 0, "<1GiB >1GiB", units.ErrEmptyRange},
+		{"1GiB-DNE", 4, "-", units.ErrValueDoesNotMatchPattern},
+		{"1GiB..2DNE", 7, "DNE", units.ErrUnrecognizedSymbol},
+		{" >=1DNE", 4, "DNE", units.ErrUnrecognizedSymbol},
+		{"<", 1, "", units.ErrValueDoesNotMatchPattern},
+		{"..", 0, "..", units.ErrValueDoesNotMatchPattern},
+		{"<1GiB <2GiB", 6, "<2GiB", units.ErrValueDoesNotMatchPattern},
+	}
+
+	for _, testCase := range testCases {
+		_, err := units.ParseRange(binary, testCase.input)
+		require.ErrorIs(t, err, testCase.cause, testCase.input)
+
+		var parseErr *units.ParseError
+		require.ErrorAs(t, err, &parseErr, testCase.input)
+		require.Equal(t, testCase.input, parseErr.Input)
+		require.Equal(t, testCase.offset, parseErr.Offset, testCase.input)
+		require.Equal(t, testCase.token, parseErr.Token, testCase.input)
+	}
+}
+
+func TestRange(t *testing.T) {
+	r, err := units.ParseRange(binary, ">1KiB <=4KiB")
+	require.NoError(t, err)
+
+	require.False(t, r.Contains(1<<10))
+	require.True(t, r.Contains(1<<10+1))
+	require.True(t, r.Contains(4<<10))
+	require.False(t, r.Contains(4<<10+1))
+
+	require.Equal(t, int64(1<<10+1), r.Clamp(0))
+	require.Equal(t, int64(2<<10), r.Clamp(2<<10))
+	require.Equal(t, int64(4<<10), r.Clamp(math.MaxInt64))
+
+	unbounded := units.Range[int64]{}
+	require.True(t, unbounded.Contains(math.MinInt64))
+	require.True(t, unbounded.Contains(math.MaxInt64))
+	require.Equal(t, int64(5), unbounded.Clamp(5))
+
+	other, err := units.ParseRange(binary, "2KiB..8KiB")
+	require.NoError(t, err)
+
+	intersection, ok := r.Intersect(other)
+	require.True(t, ok)
+	require.Equal(t, "2KiB..4KiB", intersection.Format(binary))
+
+	intersection, ok = other.Intersect(units.Range[int64]{Max: 8 << 10, HasMax: true, ExclusiveMax: true})
+	require.True(t, ok)
+	require.Equal(t, ">=2KiB <8KiB", intersection.Format(binary))
+
+	intersection, ok = r.Intersect(units.Range[int64]{})
+	require.True(t, ok)
+	require.Equal(t, r, intersection)
+
+	_, ok = r.Intersect(units.Range[int64]{Min: 4 << 10, HasMin: true, ExclusiveMin: true})
+	require.False(t, ok)
+
+	empty := units.Range[int8]{Min: math.MaxInt8, HasMin: true, ExclusiveMin: true}
+	require.True(t, empty.Empty())
+	require.False(t, empty.Contains(math.MaxInt8))
+	require.Equal(t, int8(3), empty.Clamp(3))
+
+	require.True(t, units.Range[uint8]{Max: 0, HasMax: true, ExclusiveMax: true}.Empty())
+	open := units.Range[int64]{Min: 1, Max: 2, HasMin: true, HasMax: true, ExclusiveMin: true, ExclusiveMax: true}
+	require.True(t, open.Empty())
+
+	// Set relies on T implementing Set
+	require.Error(t, new(units.Range[int64]).Set("1..2"))
+	require.Equal(t, "1..2", units.Range[int64]{Min: 1, Max: 2, HasMin: true, HasMax: true}.String())
+}
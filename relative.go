@@ -0,0 +1,209 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Relative describes a value that is resolved against a total, such as a limit expressed as a percentage of the
+// available memory (for example, "80%") or as headroom below it (for example, "total-2GiB"). The resolved value is the
+// Percent of the total, plus or minus the Offset. Absolute values are represented using a Percent of zero. The zero
+// value resolves to zero.
+//
+// Relative implements the same Set and String methods as the quantity types, allowing a flag to carry a relative value.
+// When used this way, T must implement Set (for example, data.Size). Otherwise, see ParseRelative and Format.
+type Relative[T Number] struct {
+	// Percent is the percentage of the total included in the value (for example, 80 for "80%" or 100 for "total").
+	Percent float64
+	// Offset is added to the percentage of the total, or subtracted from it when Subtract is set.
+	Offset   T
+	Subtract bool
+}
+
+// ParseRelative parses the provided value using the Unit to parse any quantities. Values are written as an absolute
+// quantity (for example, "4GiB"), a percentage of the total (for example, "80%"), or the total itself ("total"). Both
+// percentages and the total may be followed by an offset (for example, "total-2GiB" or "50%+512MiB"). An empty string
+// resolves to zero.
+func ParseRelative[T Number](u Unit[T], val string, opts ...Option) (Relative[T], error) {
+	options := apply(opts)
+
+	idx, err := newIndex(u, options)
+	if err != nil {
+		return Relative[T]{}, err
+	}
+
+	return parseRelative(val, func(s string) (T, error) {
+		return parse(u, idx, s, options)
+	})
+}
+
+// parseRelative parses the provided value using the provided function to parse any quantities. The zero value is
+// returned along with any error.
+func parseRelative[T Number](val string, parse func(string) (T, error)) (r Relative[T], err error) {
+	offset := len(val) - len(strings.TrimLeftFunc(val, unicode.IsSpace))
+	s := strings.TrimSpace(val)
+
+	var i int
+	switch percent := strings.IndexByte(s, '%'); {
+	case s == "":
+		return r, nil
+	case len(s) >= 5 && strings.EqualFold(s[:5], "total"):
+		r.Percent, i = 100, 5
+	case percent >= 0:
+		measure := strings.TrimSpace(s[:percent])
+
+		r.Percent, err = strconv.ParseFloat(measure, 64)
+		if err != nil || r.Percent < 0 || math.IsInf(r.Percent, 0) || math.IsNaN(r.Percent) {
+			return Relative[T]{}, &ParseError{val, offset, measure, ErrInvalidNumber}
+		}
+
+		i = percent + 1
+	default:
+		r.Offset, err = parseAt(val, offset, s, parse)
+		if err != nil {
+			return Relative[T]{}, err
+		}
+
+		return r, nil
+	}
+
+	rest := strings.TrimLeftFunc(s[i:], unicode.IsSpace)
+	i = len(s) - len(rest)
+
+	switch {
+	case rest == "":
+		return r, nil
+	case rest[0] != '+' && rest[0] != '-':
+		return Relative[T]{}, &ParseError{val, offset + i, nextToken(rest), ErrValueDoesNotMatchPattern}
+	}
+
+	r.Subtract = rest[0] == '-'
+
+	quantity := strings.TrimLeftFunc(rest[1:], unicode.IsSpace)
+	if quantity == "" || quantity[0] == '+' || quantity[0] == '-' {
+		return Relative[T]{}, &ParseError{val, offset + i + 1, nextToken(quantity), ErrValueDoesNotMatchPattern}
+	}
+
+	r.Offset, err = parseAt(val, offset+len(s)-len(quantity), quantity, parse)
+	if err != nil {
+		return Relative[T]{}, err
+	}
+
+	return r, nil
+}
+
+// Format renders the value using the Unit to format any quantities. Percentages are rendered using the shortest
+// representation of Percent, and a Percent of 100 is rendered as "total".
+func (r Relative[T]) Format(u Unit[T], opts ...Option) string {
+	options := apply(opts)
+
+	return r.format(func(value T) string {
+		return u.format(value, options)
+	})
+}
+
+// format renders the value using the provided function to format any quantities.
+func (r Relative[T]) format(format func(T) string) string {
+	var str string
+	switch r.Percent {
+	case 0:
+		if !r.Subtract {
+			return format(r.Offset)
+		}
+
+		str = "0%"
+	case 100:
+		str = "total"
+	default:
+		str = strconv.FormatFloat(r.Percent, 'f', -1, 64) + "%"
+	}
+
+	switch {
+	case r.Offset == 0:
+		return str
+	case r.Subtract:
+		return str + "-" + format(r.Offset)
+	}
+
+	return str + "+" + format(r.Offset)
+}
+
+// Set parses the provided value using the Set method of T, allowing relative values to be used as flags. See
+// ParseRelative for the accepted syntax.
+func (r *Relative[T]) Set(val string) error {
+	v, err := parseRelative(val, set[T])
+	if err != nil {
+		return err
+	}
+
+	*r = v
+	return nil
+}
+
+// String renders the value using the default format of T (for example, the String method of data.Size). See Format.
+func (r Relative[T]) String() string {
+	return r.format(func(value T) string {
+		return fmt.Sprint(value)
+	})
+}
+
+// Resolve returns the value relative to the provided total. Percentages are computed exactly and truncated towards zero
+// before the Offset is applied. Results that cannot be represented by T saturate at the limits of T (for example,
+// "total-2GiB" resolves to zero for an unsigned total smaller than 2GiB).
+func (r Relative[T]) Resolve(total T) T {
+	value := ToBig(total)
+	if r.Percent != 100 {
+		scaled := new(big.Rat).Mul(new(big.Rat).SetInt(value), exact(r.Percent))
+		value.Quo(scaled.Num(), new(big.Int).Mul(scaled.Denom(), big.NewInt(100)))
+	}
+
+	if r.Subtract {
+		value.Sub(value, ToBig(r.Offset))
+	} else {
+		value.Add(value, ToBig(r.Offset))
+	}
+
+	return saturate[T](value)
+}
+
+// saturate converts the provided value to T, clamping it to the range of T.
+func saturate[T Number](value *big.Int) T {
+	signed, max := limits[T]()
+
+	mag := new(big.Int).Abs(value)
+	switch {
+	case value.Sign() < 0 && !signed:
+		return 0
+	case value.Sign() < 0 && mag.Cmp(new(big.Int).SetUint64(max+1)) > 0:
+		mag.SetUint64(max + 1)
+	case value.Sign() >= 0 && mag.Cmp(new(big.Int).SetUint64(max)) > 0:
+		mag.SetUint64(max)
+	}
+
+	result, _ := fromMagnitude[T](value.Sign() < 0, mag.Uint64())
+	return result
+}
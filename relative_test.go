@@ -0,0 +1,108 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mjpitz/units"
+)
+
+func TestParseRelative(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected units.Relative[int64]
+		str      string
+		resolved int64
+	}{
+		{"", units.Relative[int64]{}, "0B", 0},
+		{"4GiB", units.Relative[int64]{Offset: 4 << 30}, "4GiB", 4 << 30},
+		{"-1KiB", units.Relative[int64]{Offset: -1 << 10}, "-1KiB", -1 << 10},
+		{"80%", units.Relative[int64]{Percent: 80}, "80%", 8 << 30 * 4 / 5},
+		{" 12.5 % ", units.Relative[int64]{Percent: 12.5}, "12.5%", 1 << 30},
+		{"total", units.Relative[int64]{Percent: 100}, "total", 8 << 30},
+		{"total-2GiB", units.Relative[int64]{Percent: 100, Offset: 2 << 30, Subtract: true}, "total-2GiB", 6 << 30},
+		{"Total - 512MiB", units.Relative[int64]{Percent: 100, Offset: 512 << 20, Subtract: true}, "total-512MiB", 7<<30 + 512<<20},
+		{"50%+1KiB", units.Relative[int64]{Percent: 50, Offset: 1 << 10}, "50%+1KiB", 4<<30 + 1<<10},
+		{"0%-1KiB", units.Relative[int64]{Offset: 1 << 10, Subtract: true}, "0%-1KiB", -1 << 10},
+		{"150%", units.Relative[int64]{Percent: 150}, "150%", 12 << 30},
+		{"33.3%", units.Relative[int64]{Percent: 33.3}, "33.3%", 2860448219},
+	}
+
+	for _, testCase := range testCases {
+		r, err := units.ParseRelative(binary, testCase.input)
+		require.NoError(t, err, testCase.input)
+		require.Equal(t, testCase.expected, r, testCase.input)
+		require.Equal(t, testCase.str, r.Format(binary), testCase.input)
+		require.Equal(t, testCase.resolved, r.Resolve(8<<30), testCase.input)
+
+		parsed, err := units.ParseRelative(binary, r.Format(binary))
+		require.NoError(t, err, testCase.input)
+		require.Equal(t, r, parsed, testCase.input)
+	}
+}
+
+func TestParseRelative_Errors(t *testing.T) {
+	testCases := []struct {
+		input  string
+		offset int
+		token  string
+		cause  error
+	}{
+		{"eighty%", 0, "eighty", units.ErrInvalidNumber},
+		{"-5%", 0, "-5", units.ErrInvalidNumber},
+		{"total*2", 5, "*", units.ErrValueDoesNotMatchPattern},
+		{"total-", 6, "", units.ErrValueDoesNotMatchPattern},
+		{"total--1GiB", 6, "-", units.ErrValueDoesNotMatchPattern},
+		{" total - 2DNE", 10, "DNE", units.ErrUnrecognizedSymbol},
+		{"4DNE", 1, "DNE", units.ErrUnrecognizedSymbol},
+	}
+
+	for _, testCase := range testCases {
+		r, err := units.ParseRelative(binary, testCase.input)
+		require.ErrorIs(t, err, testCase.cause, testCase.input)
+		require.Zero(t, r, testCase.input)
+
+		var parseErr *units.ParseError
+		require.ErrorAs(t, err, &parseErr, testCase.input)
+		require.Equal(t, testCase.input, parseErr.Input)
+		require.Equal(t, testCase.offset, parseErr.Offset, testCase.input)
+		require.Equal(t, testCase.token, parseErr.Token, testCase.input)
+	}
+}
+
+func TestRelative_Resolve(t *testing.T) {
+	headroom := units.Relative[uint64]{Percent: 100, Offset: 2 << 30, Subtract: true}
+	require.Equal(t, uint64(6<<30), headroom.Resolve(8<<30))
+	require.Equal(t, uint64(0), headroom.Resolve(1<<30))
+
+	double := units.Relative[int64]{Percent: 200}
+	require.Equal(t, int64(math.MaxInt64), double.Resolve(math.MaxInt64))
+	require.Equal(t, int64(math.MinInt64), double.Resolve(math.MinInt64))
+	require.Equal(t, int64(-3), units.Relative[int64]{Percent: 50}.Resolve(-7))
+
+	// Set relies on T implementing Set
+	require.Error(t, new(units.Relative[int64]).Set("total-1"))
+	require.Equal(t, "total-5", units.Relative[int64]{Percent: 100, Offset: 5, Subtract: true}.String())
+}
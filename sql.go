@@ -0,0 +1,123 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// SQL wraps a value so that it can be scanned from a database. The quantity types implement driver.Valuer, storing
+// values as integers in their base unit, but cannot implement sql.Scanner as their Scan method implements fmt.Scanner.
+// Values are stored the same way, while scanning accepts integer columns as well as []byte and string columns parsed
+// using the Set method of T (for example, "512MiB" or "536870912"). See SQLString to store strings instead.
+type SQL[T Number] struct {
+	Quantity T
+}
+
+// Value implements driver.Valuer, returning ErrOverflow if the value exceeds the range of int64.
+func (s SQL[T]) Value() (driver.Value, error) {
+	v, err := Convert[int64](s.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// Scan implements sql.Scanner.
+func (s *SQL[T]) Scan(src interface{}) error {
+	v, err := scanSQL(src, setSQL[T])
+	if err != nil {
+		return err
+	}
+
+	s.Quantity = v
+	return nil
+}
+
+// SQLString wraps a value so that it's stored in a database as a canonical string (for example, "1GiB512MiB") rather
+// than as an integer in its base unit. Strings are rendered and parsed using the Unit, rounding to the nearest base
+// unit, or the String and Set methods of T when no Unit is configured. Scanning accepts integer, []byte and string
+// columns, so the wrapper can also read columns written by the Value method of the quantity types, and strings holding
+// only an integer are read in the base unit (see BareIntegers).
+type SQLString[T Number] struct {
+	Quantity T
+	Unit     Unit[T]
+}
+
+// Value implements driver.Valuer.
+func (s SQLString[T]) Value() (driver.Value, error) {
+	if len(s.Unit) == 0 {
+		return fmt.Sprint(s.Quantity), nil
+	}
+
+	return s.Unit.Format(s.Quantity), nil
+}
+
+// Scan implements sql.Scanner, leaving the Unit of the wrapper unchanged.
+func (s *SQLString[T]) Scan(src interface{}) error {
+	parse := setSQL[T]
+	if len(s.Unit) > 0 {
+		parse = func(val string) (T, error) {
+			return s.Unit.Parse(val, BareIntegers())
+		}
+	}
+
+	v, err := scanSQL(src, parse)
+	if err != nil {
+		return err
+	}
+
+	s.Quantity = v
+	return nil
+}
+
+// setSQL parses the provided value using the Set method of T, reading integers in the base unit (see BareIntegers) so
+// that types without one can still be scanned from the strings rendered by SQLString.
+func setSQL[T Number](val string) (T, error) {
+	if digits := strings.TrimSpace(val); isInteger(digits) {
+		return parseBare[T](val, digits)
+	}
+
+	return set[T](val)
+}
+
+// scanSQL converts a column value of one of the types returned by drivers for integer and text columns into a value,
+// using the provided parse func for strings.
+func scanSQL[T Number](src interface{}, parse func(string) (T, error)) (T, error) {
+	var text string
+	switch src := src.(type) {
+	case int64:
+		return Convert[T](src)
+	case []byte:
+		text = string(src)
+	case string:
+		text = src
+	case nil:
+		return 0, fmt.Errorf("cannot scan NULL into %T", *new(T))
+	default:
+		return 0, fmt.Errorf("cannot scan %T into %T", src, *new(T))
+	}
+
+	return parse(text)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mjpitz/units"
+)
+
+var (
+	_ sql.Scanner   = &units.SQL[int64]{}
+	_ driver.Valuer = units.SQL[int64]{}
+	_ sql.Scanner   = &units.SQLString[int64]{}
+	_ driver.Valuer = units.SQLString[int64]{}
+)
+
+func TestSQL(t *testing.T) {
+	stored, err := units.SQL[uint32]{1 << 31}.Value()
+	require.NoError(t, err)
+	require.Equal(t, int64(1<<31), stored)
+
+	_, err = units.SQL[uint64]{1 << 63}.Value()
+	require.ErrorIs(t, err, units.ErrOverflow)
+
+	for _, src := range []interface{}{int64(1024), []byte("1024"), " +1024 "} {
+		var scanned units.SQL[uint16]
+		require.NoError(t, scanned.Scan(src), src)
+		require.Equal(t, uint16(1024), scanned.Quantity, src)
+	}
+
+	var scanned units.SQL[uint8]
+	require.ErrorIs(t, scanned.Scan(int64(256)), units.ErrOverflow)
+	require.ErrorIs(t, scanned.Scan("-1"), units.ErrNegative)
+	require.EqualError(t, scanned.Scan(nil), "cannot scan NULL into uint8")
+	require.EqualError(t, scanned.Scan(1.5), "cannot scan float64 into uint8")
+
+	// strings other than integers can only be parsed when the type implements Set
+	require.EqualError(t, scanned.Scan("1KiB"), "uint8 does not implement Set")
+}
+
+func TestSQLString(t *testing.T) {
+	value := int64(1<<30 + 512<<20)
+
+	stored, err := units.SQLString[int64]{value, binary}.Value()
+	require.NoError(t, err)
+	require.Equal(t, "1GiB512MiB", stored)
+	require.True(t, driver.IsValue(stored))
+
+	testCases := []struct {
+		src      interface{}
+		expected int64
+	}{
+		{"1GiB512MiB", value},
+		{[]byte("1.5GiB"), value},
+		{" 1 gibibyte 512 mebibytes ", value},
+		{int64(1610612736), value},
+		{[]byte("1610612736"), value},
+		{"-1024", -1 << 10},
+		{"+1024", 1 << 10},
+		{"1.0000000001GiB", 1 << 30},
+		{"", 0},
+	}
+
+	for _, testCase := range testCases {
+		scanned := units.SQLString[int64]{Unit: binary}
+		require.NoError(t, scanned.Scan(testCase.src), testCase.src)
+		require.Equal(t, testCase.expected, scanned.Quantity, testCase.src)
+		require.Len(t, scanned.Unit, len(binary))
+	}
+
+	scanned := units.SQLString[int64]{Unit: binary}
+	for _, src := range []interface{}{nil, 1.5, true, "1DNE", "1-2", []byte("99999999999999999999")} {
+		require.Error(t, scanned.Scan(src), src)
+	}
+
+	small := units.SQLString[uint8]{}
+	require.ErrorIs(t, small.Scan(int64(-1)), units.ErrNegative)
+	require.ErrorIs(t, small.Scan(int64(256)), units.ErrOverflow)
+	require.ErrorIs(t, small.Scan("-1"), units.ErrNegative)
+	require.NoError(t, small.Scan(int64(255)))
+	require.Equal(t, uint8(255), small.Quantity)
+
+	// without a unit, strings can only be parsed when the type implements Set
+	unitless := units.SQLString[int64]{}
+	require.Error(t, unitless.Scan("1GiB"))
+	require.NoError(t, unitless.Scan("1024"))
+	require.Equal(t, int64(1024), unitless.Quantity)
+
+	stored, err = unitless.Value()
+	require.NoError(t, err)
+	require.Equal(t, "1024", stored)
+}
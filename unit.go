@@ -22,9 +22,9 @@ package units
 
 import (
 	"fmt"
-	"regexp"
+	"math/big"
+	"math/bits"
 	"strconv"
-	"strings"
 
 	"golang.org/x/exp/constraints"
 )
@@ -33,21 +33,116 @@ var (
 	// ErrValueDoesNotMatchPattern notifies the caller that the provided string text did not match our expected format.
 	ErrValueDoesNotMatchPattern = fmt.Errorf("value does not match pattern")
 
-	format = regexp.MustCompile("(?P<measure>[0-9.]+)(?P<symbol>[a-zA-Z ]{1,6})")
+	// ErrMissingSymbol notifies the caller that a measure was provided without an accompanying symbol.
+	ErrMissingSymbol = fmt.Errorf("missing symbol")
+
+	// ErrUnrecognizedSymbol notifies the caller that a symbol was not found in the Unit being parsed.
+	ErrUnrecognizedSymbol = fmt.Errorf("unrecognized symbol")
+
+	// ErrInvalidNumber notifies the caller that a measure could not be interpreted as a number.
+	ErrInvalidNumber = fmt.Errorf("invalid number")
+
+	// ErrInexact notifies the caller that a value cannot be represented exactly in the base unit.
+	ErrInexact = fmt.Errorf("value cannot be represented exactly")
+
+	// ErrNegative notifies the caller that a negative value was provided for an unsigned type.
+	ErrNegative = fmt.Errorf("unsigned value cannot be negative")
 )
 
+// ParseError describes a problem encountered while parsing a value. It records the original input along with the byte
+// offset and token where parsing failed so callers can point users to the exact location of the problem. The
+// underlying cause is available through errors.Is and errors.As.
+type ParseError struct {
+	Input  string
+	Offset int
+	Token  string
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("%v at offset %d of %q", e.Err, e.Offset, e.Input)
+	}
+
+	return fmt.Sprintf("%v %q at offset %d of %q", e.Err, e.Token, e.Offset, e.Input)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
 // Number defines a constraint to ensure the values provided to units are integer based (i.e. we're working with whole
 // numbers). This makes sure we're working with whole numbers and not handling fractions internally. This forces the
-// programmer to handle all rounding and truncation.
+// programmer to handle all rounding and truncation. Both signed and unsigned integers are supported, allowing values
+// such as the uint64 counters reported by the runtime to be used directly.
 type Number interface {
-	constraints.Signed
+	constraints.Integer
 }
 
 // Symbol defines how various sizes should be labeled. Some values may contain multiple labels, but the preferred label
-// that will be used when printing should come first in the list.
-type Symbol[T Number] struct {
+// that will be used when printing should come first in the list. Symbols may optionally carry long-form Names, holding
+// the singular name followed by the plural name (for example, "foot" and "feet"). When only the singular name is
+// provided, the plural is formed by appending an "s". Parse accepts names in addition to labels. Sizes are integers
+// when used by a Unit and floating point values when used by a FloatUnit.
+type Symbol[T Number | Float] struct {
 	Size  T
 	Label []string
+	Names []string
+}
+
+// Singular returns the singular name of the symbol, falling back to its preferred label when no names are declared.
+func (s Symbol[T]) Singular() string {
+	return singular(s.Label, s.Names)
+}
+
+// Plural returns the plural name of the symbol, falling back to its preferred label when no names are declared.
+func (s Symbol[T]) Plural() string {
+	return plural(s.Label, s.Names)
+}
+
+func singular(label, names []string) string {
+	if len(names) == 0 {
+		return label[0]
+	}
+
+	return names[0]
+}
+
+func plural(label, names []string) string {
+	switch len(names) {
+	case 0:
+		return label[0]
+	case 1:
+		return names[0] + "s"
+	}
+
+	return names[1]
+}
+
+// spellings returns every label and name Parse should accept for a symbol, including the labels localized by the
+// provided Locale.
+func spellings(label, names []string, locale *Locale) []string {
+	var localized []string
+	if locale != nil {
+		for _, l := range label {
+			if symbol, ok := locale.Symbols[l]; ok {
+				localized = append(localized, symbol)
+			}
+		}
+	}
+
+	if len(names) == 0 && len(localized) == 0 {
+		return label
+	}
+
+	spellings := make([]string, 0, len(label)+len(names)+len(localized)+1)
+	spellings = append(spellings, label...)
+	spellings = append(spellings, names...)
+	if len(names) == 1 {
+		spellings = append(spellings, plural(label, names))
+	}
+
+	return append(spellings, localized...)
 }
 
 // A Unit of measure is a standardized quantity used to quantify and express the magnitude or value of a physical
@@ -56,86 +151,342 @@ type Symbol[T Number] struct {
 // quantities in various domains, such as length, mass, time, temperature, volume, and many others.
 type Unit[T Number] []Symbol[T]
 
+// table abstracts over the symbols of a Unit or BigUnit so that both can share the same index, scanner, and rendering
+// of labels.
+type table interface {
+	// count returns the number of symbols in the table.
+	count() int
+	// symbol returns the labels and names of the i-th symbol.
+	symbol(i int) (label, names []string)
+	// same reports whether the i-th and j-th symbols have the same size.
+	same(i, j int) bool
+}
+
+func (u Unit[T]) count() int {
+	return len(u)
+}
+
+func (u Unit[T]) symbol(i int) (label, names []string) {
+	return u[i].Label, u[i].Names
+}
+
+func (u Unit[T]) same(i, j int) bool {
+	return u[i].Size == u[j].Size
+}
+
 // Format uses the underlying Unit to convert the provided value to a human-readable string. The benefit to this
 // abstraction is that so long as a unit shares a common base unit, multiple formats can be used to represent the
-// underlying value (for example, metric vs imperial).
-func (u Unit[T]) Format(value T, opts ...Option) (str string) {
-	if len(u) == 0 || value == 0 {
+// underlying value (for example, metric vs imperial). By default, values are broken down into their components (for
+// example, "1GiB512MiB"). See Largest and Fixed for alternative styles, and Verbose for rendering long-form names.
+func (u Unit[T]) Format(value T, opts ...Option) string {
+	return u.format(value, apply(opts))
+}
+
+// format renders the value using options that have already been applied.
+func (u Unit[T]) format(value T, options Options) (str string) {
+	if len(u) == 0 {
 		return ""
 	}
 
-	options := Options{'f', -1}
-	for _, opt := range opts {
-		opt.Apply(&options)
+	if value == 0 {
+		return renderZero(u, options)
+	}
+
+	neg, mag := magnitude(value)
+	switch {
+	case neg:
+		str = "-"
+	case options.ExplicitPlus:
+		str = "+"
+	}
+
+	switch options.Style {
+	case StyleLargest:
+		return str + u.single(mag, u.carry(mag, u.largest(mag), options), options)
+	case StyleFixed:
+		i := find(u, options.Symbol)
+		if i < 0 {
+			i = u.largest(mag)
+		}
+
+		return str + u.single(mag, i, options)
+	}
+
+	if options.MaxComponents > 0 {
+		mag = u.limit(neg, mag, options.MaxComponents)
 	}
 
-	for i := len(u); value > 0 && i > 1; i-- {
-		if value >= u[i-1].Size {
-			str += strconv.FormatInt(int64(value/u[i-1].Size), 10) + u[i-1].Label[0]
-			value = value % u[i-1].Size
+	// verbose and spaced components are separated by a space (for example, "1 gibibyte 512 mebibytes")
+	first := len(str)
+	separate := func() {
+		if (options.Verbose || options.Locale != nil && options.Locale.Space) && len(str) > first {
+			str += " "
 		}
 	}
 
-	if value > 0 {
-		rem := float64(value) / float64(u[0].Size)
-		str += strconv.FormatFloat(rem, options.Format, options.Precision, 64) + u[0].Label[0]
+	for i := len(u); mag > 0 && i > 1; i-- {
+		size := uint64(u[i-1].Size)
+		if mag >= size {
+			separate()
+			str += render(u, strconv.FormatUint(mag/size, 10), i-1, options)
+			mag = mag % size
+		}
+	}
+
+	if mag > 0 {
+		separate()
+		str += u.single(mag, 0, options)
 	}
 
 	return str
 }
 
-// Parse attempts to convert the provided string value to its equivalent numeric representation.
-func (u Unit[T]) Parse(val string) (size T, err error) {
-	val = strings.TrimSpace(val)
-	if val == "" || val == "0" {
-		return 0, nil
+// limit rounds the magnitude so that it can be represented using at most n components. The remainder is rounded (half
+// away from zero) into the least significant component that is kept, carrying into larger components as needed. When
+// rounding up would exceed the range of T, the remainder is rounded down instead.
+func (u Unit[T]) limit(neg bool, mag uint64, n int) uint64 {
+	signed, max := limits[T]()
+	if neg && signed {
+		max++
 	}
 
-	var factor T = 1
-	switch val[0] {
-	case '-':
-		factor = T(-1)
-		val = val[1:]
-	case '+':
-		val = val[1:]
+	for {
+		components := 0
+		for i, rem := len(u)-1, mag; rem > 0 && i >= 0; i-- {
+			size := uint64(u[i].Size)
+			if rem < size && i > 0 {
+				continue
+			}
+
+			components++
+			if components == n {
+				if i == 0 {
+					return mag
+				}
+
+				// only the remainder is rounded since larger symbols need not be multiples of this one
+				rounded, ok := roundTo(rem, size)
+				sum, carry := bits.Add64(mag-rem, rounded, 0)
+				if !ok || carry != 0 || sum > max {
+					sum = mag - rem%size
+				}
+
+				if sum == mag {
+					return mag
+				}
+
+				mag = sum
+				break
+			}
+
+			rem = rem % size
+		}
+
+		if components < n {
+			return mag
+		}
 	}
+}
 
-	// todo: improve this so we don't need regex
-	if !format.MatchString(val) {
-		return size, ErrValueDoesNotMatchPattern
+// roundTo rounds the magnitude to the nearest multiple of size, rounding half away from zero. When the result cannot be
+// represented, false is returned.
+func roundTo(mag, size uint64) (uint64, bool) {
+	q, r := mag/size, mag%size
+	if r >= size-r {
+		q++
 	}
 
-	// todo: memoize this
-	idx := make(map[string]T)
-	for i := len(u); i > 0; i-- {
-		for _, label := range u[i-1].Label {
-			idx[label] = u[i-1].Size
+	hi, lo := bits.Mul64(q, size)
+	return lo, hi == 0
+}
+
+// single renders the magnitude as a decimal number of the i-th symbol.
+func (u Unit[T]) single(mag uint64, i int, options Options) string {
+	return render(u, u.number(mag, i, options), i, options)
+}
+
+// number renders the magnitude as a decimal number of the i-th symbol without its label.
+func (u Unit[T]) number(mag uint64, i int, options Options) string {
+	size := uint64(u[i].Size)
+	return decimal(mag/size, float64(mag%size)/float64(size), options)
+}
+
+// carry moves up from the i-th symbol while the magnitude, once rounded to the configured precision, reaches the size
+// of the next symbol (for example, rendering 1023.999MiB as "1.00GiB" rather than "1024.00MiB").
+func (u Unit[T]) carry(mag uint64, i int, options Options) int {
+	for ; i+1 < len(u); i++ {
+		size := new(big.Rat).SetUint64(uint64(u[i].Size))
+		next := new(big.Rat).SetUint64(uint64(u[i+1].Size))
+		if !carries(u.number(mag, i, options), size, next) {
+			break
 		}
 	}
 
-	for _, match := range format.FindAllStringSubmatch(val, -1) {
-		label := strings.TrimSpace(match[2])
-		unit, ok := idx[label]
-		if !ok {
-			return 0, fmt.Errorf("unrecognized symbol: %s", label)
+	return i
+}
+
+// carries reports whether the rendered number of a symbol with the provided size reaches the size of the next symbol.
+func carries(number string, size, next *big.Rat) bool {
+	value, ok := new(big.Rat).SetString(number)
+	return ok && value.Mul(value, size).Cmp(next) >= 0
+}
+
+// render appends the preferred label of the i-th symbol to the rendered number. When Verbose is configured, the
+// singular or plural name is appended instead, using the singular name only when the number is exactly one. When a
+// Locale is configured, the number and label are localized.
+func render(t table, number string, i int, options Options) string {
+	label, names := t.symbol(i)
+
+	locale := options.Locale
+	switch {
+	case options.Verbose && number == "1":
+		return number + " " + singular(label, names)
+	case options.Verbose:
+		return locale.number(number) + " " + plural(label, names)
+	case locale != nil && locale.Space:
+		return locale.number(number) + " " + locale.symbol(label[0])
+	}
+
+	return locale.number(number) + locale.symbol(label[0])
+}
+
+// decimal renders the sum of a whole number and a fraction (between 0 and 1) using the configured format and precision.
+// When using the 'f' format, the whole number is rendered exactly instead of being subject to floating point precision.
+func decimal(whole uint64, frac float64, options Options) string {
+	if options.Format != 'f' {
+		return strconv.FormatFloat(float64(whole)+frac, options.Format, options.Precision, 64)
+	}
+
+	// fractions are rendered as "0.ddd" unless rounding carries them over to "1.ddd"
+	str := strconv.FormatFloat(frac, 'f', options.Precision, 64)
+	if str[0] == '1' {
+		whole++
+	}
+
+	return strconv.FormatUint(whole, 10) + str[1:]
+}
+
+// largest returns the index of the largest symbol the magnitude fills. When the magnitude is smaller than every symbol,
+// the smallest symbol is returned.
+func (u Unit[T]) largest(mag uint64) int {
+	for i := len(u) - 1; i > 0; i-- {
+		if mag >= uint64(u[i].Size) {
+			return i
+		}
+	}
+
+	return 0
+}
+
+// find returns the index of the symbol with the provided label, or -1 if no symbol uses it.
+func find(t table, label string) int {
+	if label == "" {
+		return -1
+	}
+
+	for i := 0; i < t.count(); i++ {
+		labels, _ := t.symbol(i)
+		for _, l := range labels {
+			if l == label {
+				return i
+			}
 		}
+	}
+
+	return -1
+}
 
-		parsed, err := strconv.ParseFloat(match[1], 64)
-		if err != nil {
-			return 0, err
+// renderZero renders the zero value using the configured placeholder or symbol. By default, the smallest symbol is used
+// unless a Fixed symbol was requested.
+func renderZero(t table, options Options) string {
+	if options.ZeroPlaceholder != "" {
+		return options.ZeroPlaceholder
+	}
+
+	if i := find(t, options.ZeroSymbol); i >= 0 {
+		if options.Verbose || options.Locale != nil {
+			return render(t, "0", i, options)
 		}
 
-		size += T(parsed * float64(unit))
+		return "0" + options.ZeroSymbol
+	}
+
+	if i := find(t, options.Symbol); i >= 0 && options.Style == StyleFixed {
+		return render(t, "0", i, options)
 	}
 
-	return factor * size, nil
+	return render(t, "0", 0, options)
 }
 
-// Options defines various formatting options that can be used to tailor a given Unit.Format call including which number
-// format is used to render a floating point number and its associated precision.
+// magnitude splits the provided value into its sign and absolute value. The absolute value is returned as an uint64 so
+// that the full range of T can be represented (including math.MinInt64).
+func magnitude[T Number](value T) (neg bool, mag uint64) {
+	mag = uint64(value)
+	if value < 0 {
+		return true, -mag
+	}
+
+	return false, mag
+}
+
+// Parse attempts to convert the provided string value to its equivalent numeric representation. Values are written as
+// a sequence of measure and symbol pairs (for example, "1GiB512MiB") with an optional leading sign. Measures are
+// handled as exact decimals, and any fraction of the base unit that remains is resolved using the configured Rounding
+// mode, which defaults to RoundHalfEven (for example, "1.5B" parses as 2 bytes). When the value cannot be parsed, a
+// *ParseError is returned describing where in the input the problem occurred. When IgnoreCase is configured and the
+// Unit contains labels that only differ by case, a *CollisionError is returned instead.
+//
+// Each call builds an index of the labels and names of the Unit, including their plural and case-folded forms. Hot
+// paths should use Compile instead, which builds the index once and reuses it for every call to Codec.Parse.
+func (u Unit[T]) Parse(val string, opts ...Option) (size T, err error) {
+	options := apply(opts)
+
+	idx, err := newIndex(u, options)
+	if err != nil {
+		return 0, err
+	}
+
+	return parse(u, idx, val, options)
+}
+
+// Options defines various options that can be used to tailor a given Unit.Format or Unit.Parse call including which
+// number format is used to render a floating point number and its associated precision.
 type Options struct {
-	Format    byte
-	Precision int
+	Format          byte
+	Precision       int
+	ExplicitPlus    bool
+	ZeroSymbol      string
+	ZeroPlaceholder string
+	Style           Style
+	Symbol          string
+	MaxComponents   int
+	Rounding        RoundingMode
+	Strict          bool
+	Verbose         bool
+	IgnoreCase      bool
+	BareIntegers    bool
+	Locale          *Locale
+}
+
+// apply returns the default Options with each of the provided options applied.
+func apply(opts []Option) Options {
+	return Options{Format: 'f', Precision: -1, Rounding: RoundHalfEven}.with(opts)
+}
+
+// with returns a copy of the Options with each of the provided options applied.
+func (o Options) with(opts []Option) Options {
+	if len(opts) == 0 {
+		return o
+	}
+
+	// options are applied through a separate pointer so the common case of no options does not allocate
+	dst := new(Options)
+	*dst = o
+	for _, opt := range opts {
+		opt.Apply(dst)
+	}
+
+	return *dst
 }
 
 // Apply this Options configured values to the destination Options configured values.
@@ -147,8 +498,82 @@ func (o Options) Apply(dst *Options) {
 	if o.Precision != 0 {
 		dst.Precision = o.Precision
 	}
+
+	if o.ExplicitPlus {
+		dst.ExplicitPlus = o.ExplicitPlus
+	}
+
+	if o.ZeroSymbol != "" {
+		dst.ZeroSymbol = o.ZeroSymbol
+	}
+
+	if o.ZeroPlaceholder != "" {
+		dst.ZeroPlaceholder = o.ZeroPlaceholder
+	}
+
+	if o.Style > 0 {
+		dst.Style = o.Style
+	}
+
+	if o.Symbol != "" {
+		dst.Symbol = o.Symbol
+	}
+
+	if o.MaxComponents > 0 {
+		dst.MaxComponents = o.MaxComponents
+	}
+
+	if o.Rounding > 0 {
+		dst.Rounding = o.Rounding
+	}
+
+	if o.Strict {
+		dst.Strict = o.Strict
+	}
+
+	if o.Verbose {
+		dst.Verbose = o.Verbose
+	}
+
+	if o.IgnoreCase {
+		dst.IgnoreCase = o.IgnoreCase
+	}
+
+	if o.BareIntegers {
+		dst.BareIntegers = o.BareIntegers
+	}
+
+	if o.Locale != nil {
+		dst.Locale = o.Locale
+	}
 }
 
+// Style determines how Format breaks down a value into symbols.
+type Style int
+
+const (
+	// StyleCompound breaks a value down into each of its components (for example, "1GiB512MiB"). This is the default.
+	StyleCompound Style = iota + 1
+	// StyleLargest renders a value as a decimal of the largest symbol it fills (for example, "1.5GiB").
+	StyleLargest
+	// StyleFixed renders a value as a decimal of a specific symbol (for example, "1536MiB").
+	StyleFixed
+)
+
+// RoundingMode determines how Parse resolves values that fall between two multiples of the base unit.
+type RoundingMode int
+
+const (
+	// RoundTruncate rounds towards zero.
+	RoundTruncate RoundingMode = iota + 1
+	// RoundFloor rounds towards negative infinity.
+	RoundFloor
+	// RoundCeil rounds towards positive infinity.
+	RoundCeil
+	// RoundHalfEven rounds to the nearest value, resolving ties to the nearest even value. This is the default.
+	RoundHalfEven
+)
+
 // Option provides a mechanism to tune specific behaviors of the system such as formatting and numeric precision.
 type Option interface {
 	Apply(*Options)
@@ -187,3 +612,101 @@ func Precision(precision int) OptionFunc {
 		opts.Precision = precision
 	}
 }
+
+// ExplicitPlus configures Format to render a leading '+' for positive values. Negative values are always rendered with
+// a leading '-'.
+func ExplicitPlus() OptionFunc {
+	return func(opts *Options) {
+		opts.ExplicitPlus = true
+	}
+}
+
+// ZeroSymbol configures Format to render zero using the provided symbol label (for example, "0MB"). When the label is
+// not part of the Unit, the smallest symbol is used instead.
+func ZeroSymbol(label string) OptionFunc {
+	return func(opts *Options) {
+		opts.ZeroSymbol = label
+	}
+}
+
+// ZeroPlaceholder configures Format to render zero using the provided text (for example, "-") instead of a symbol.
+func ZeroPlaceholder(placeholder string) OptionFunc {
+	return func(opts *Options) {
+		opts.ZeroPlaceholder = placeholder
+	}
+}
+
+// Compound configures Format to break a value down into each of its components (for example, "1GiB512MiB").
+func Compound() OptionFunc {
+	return func(opts *Options) {
+		opts.Style = StyleCompound
+	}
+}
+
+// Largest configures Format to render a value as a decimal of the largest symbol it fills (for example, "1.5GiB"). The
+// Format and Precision options control how the decimal is rendered.
+func Largest() OptionFunc {
+	return func(opts *Options) {
+		opts.Style = StyleLargest
+	}
+}
+
+// Fixed configures Format to always render a value as a decimal of the symbol with the provided label (for example,
+// "1536MiB"). When the label is not part of the Unit, the largest symbol the value fills is used instead.
+func Fixed(label string) OptionFunc {
+	return func(opts *Options) {
+		opts.Style = StyleFixed
+		opts.Symbol = label
+	}
+}
+
+// MaxComponents configures Format to render at most n components when breaking down a value (for example, "1mi1234yd"
+// instead of "1mi1234yd2ft7in"). The remainder is rounded into the last component that is rendered.
+func MaxComponents(n int) OptionFunc {
+	return func(opts *Options) {
+		opts.MaxComponents = n
+	}
+}
+
+// Rounding configures how Parse resolves values that cannot be represented exactly in the base unit. The default is
+// RoundHalfEven.
+func Rounding(mode RoundingMode) OptionFunc {
+	return func(opts *Options) {
+		opts.Rounding = mode
+	}
+}
+
+// Strict configures Parse to return ErrInexact when a value cannot be represented exactly in the base unit instead of
+// rounding it.
+func Strict() OptionFunc {
+	return func(opts *Options) {
+		opts.Strict = true
+	}
+}
+
+// Verbose configures Format to render the long-form names of symbols instead of their labels (for example, "1 gibibyte
+// 512 mebibytes"). Symbols without names are rendered using their preferred label.
+func Verbose() OptionFunc {
+	return func(opts *Options) {
+		opts.Verbose = true
+	}
+}
+
+// IgnoreCase configures Parse to match labels and names without regard to case (for example, accepting "512mib" for
+// "512MiB"). Units containing labels of different sizes that only differ by case are rejected with a *CollisionError
+// rather than guessing which symbol was meant.
+func IgnoreCase() OptionFunc {
+	return func(opts *Options) {
+		opts.IgnoreCase = true
+	}
+}
+
+// BareIntegers configures Parse to read values holding only an integer in the base unit (for example, accepting "512"
+// as 512 bytes) rather than returning ErrMissingSymbol. The quantity types only accept them when decoding JSON, YAML
+// and database values, which may hold values stored as integers, while Set and UnmarshalText reject them since a
+// missing symbol is more likely to be a mistake (for example, "-distance 5").
+func BareIntegers() OptionFunc {
+	return func(opts *Options) {
+		opts.BareIntegers = true
+	}
+}
@@ -1,6 +1,7 @@
 package units_test
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -9,7 +10,7 @@ import (
 )
 
 func TestOptions(t *testing.T) {
-	options := units.Options{'f', -1}
+	options := units.Options{Format: 'f', Precision: -1}
 	original := options
 
 	units.Precision(2).Apply(&options)
@@ -18,7 +19,255 @@ func TestOptions(t *testing.T) {
 	units.Format('b').Apply(&options)
 	require.Equal(t, byte('b'), options.Format, "format was not set properly")
 
+	units.ExplicitPlus().Apply(&options)
+	require.True(t, options.ExplicitPlus, "explicit plus was not set properly")
+
+	units.ZeroSymbol("MB").Apply(&options)
+	require.Equal(t, "MB", options.ZeroSymbol, "zero symbol was not set properly")
+
+	units.ZeroPlaceholder("-").Apply(&options)
+	require.Equal(t, "-", options.ZeroPlaceholder, "zero placeholder was not set properly")
+
+	units.Fixed("MiB").Apply(&options)
+	require.Equal(t, units.StyleFixed, options.Style, "style was not set properly")
+	require.Equal(t, "MiB", options.Symbol, "symbol was not set properly")
+
+	units.Largest().Apply(&options)
+	require.Equal(t, units.StyleLargest, options.Style, "style was not set properly")
+
+	units.Rounding(units.RoundHalfEven).Apply(&options)
+	require.Equal(t, units.RoundHalfEven, options.Rounding, "rounding was not set properly")
+
+	units.Strict().Apply(&options)
+	require.True(t, options.Strict, "strict was not set properly")
+
+	units.Verbose().Apply(&options)
+	require.True(t, options.Verbose, "verbose was not set properly")
+
 	original.Apply(&options)
 	require.Equal(t, -1, options.Precision, "precision was not reset properly")
 	require.Equal(t, byte('f'), options.Format, "format was not reset properly")
 }
+
+func TestFormat_Zero(t *testing.T) {
+	require.Equal(t, "", units.Unit[int64]{}.Format(0))
+	require.Equal(t, "0B", binary.Format(0))
+	require.Equal(t, "0MiB", binary.Format(0, units.ZeroSymbol("MiB")))
+	require.Equal(t, "0B", binary.Format(0, units.ZeroSymbol("DNE")))
+	require.Equal(t, "-", binary.Format(0, units.ZeroPlaceholder("-")))
+	require.Equal(t, "-", binary.Format(0, units.ZeroSymbol("MiB"), units.ZeroPlaceholder("-")))
+	require.Equal(t, "1KiB", binary.Format(1<<10, units.ZeroPlaceholder("-")))
+
+	for _, str := range []string{binary.Format(0), binary.Format(0, units.ZeroSymbol("MiB"))} {
+		parsed, err := binary.Parse(str)
+		require.NoError(t, err)
+		require.Equal(t, int64(0), parsed)
+	}
+}
+
+func TestFormat_Style(t *testing.T) {
+	value := int64(1<<30 + 512<<20)
+
+	require.Equal(t, "1GiB512MiB", binary.Format(value))
+	require.Equal(t, "1GiB512MiB", binary.Format(value, units.Largest(), units.Compound()))
+	require.Equal(t, "1.5GiB", binary.Format(value, units.Largest()))
+	require.Equal(t, "-1.5GiB", binary.Format(-value, units.Largest()))
+	require.Equal(t, "+1.50GiB", binary.Format(value, units.Largest(), units.Precision(2), units.ExplicitPlus()))
+	require.Equal(t, "1.5e+00GiB", binary.Format(value, units.Largest(), units.Format('e'), units.Precision(1)))
+	require.Equal(t, "512B", binary.Format(512, units.Largest()))
+	require.Equal(t, "0.5B", units.Unit[int64]{{2, []string{"B"}, nil}}.Format(1, units.Largest()))
+	require.Equal(t, "1.0MiB", binary.Format(1<<20-40, units.Largest(), units.Precision(1)))
+	require.Equal(t, "1.00GiB", binary.Format(1<<30-1, units.Largest(), units.Precision(2)))
+	require.Equal(t, "1023.98MiB", binary.Format(1<<30-1<<14, units.Largest(), units.Precision(2)))
+	require.Equal(t, "1.0e+03MiB", binary.Format(1<<30-1, units.Largest(), units.Format('e'), units.Precision(1)))
+	require.Equal(t, "9007199254740993B", binary.Format(1<<53+1, units.Fixed("B")))
+
+	require.Equal(t, "1536MiB", binary.Format(value, units.Fixed("MiB")))
+	require.Equal(t, "0.001MiB", binary.Format(1<<10, units.Fixed("MiB"), units.Precision(3)))
+	require.Equal(t, "1.5GiB", binary.Format(value, units.Fixed("DNE")))
+	require.Equal(t, "0MiB", binary.Format(0, units.Fixed("MiB")))
+	require.Equal(t, "0B", binary.Format(0, units.Largest()))
+
+	parsed, err := binary.Parse(binary.Format(value, units.Largest()))
+	require.NoError(t, err)
+	require.Equal(t, value, parsed)
+}
+
+func TestFormat_Verbose(t *testing.T) {
+	value := int64(1<<30 + 512<<20)
+
+	require.Equal(t, "1 gibibyte 512 mebibytes", binary.Format(value, units.Verbose()))
+	require.Equal(t, "-1 gibibyte 512 mebibytes", binary.Format(-value, units.Verbose()))
+	require.Equal(t, "1 gibibyte 1 byte", binary.Format(1<<30+1, units.Verbose()))
+	require.Equal(t, "1.5 gibibytes", binary.Format(value, units.Verbose(), units.Largest()))
+	require.Equal(t, "1.00 gibibytes", binary.Format(1<<30, units.Verbose(), units.Largest(), units.Precision(2)))
+	require.Equal(t, "1 kibibyte", binary.Format(1<<10, units.Verbose(), units.Fixed("KiB")))
+	require.Equal(t, "0 bytes", binary.Format(0, units.Verbose()))
+	require.Equal(t, "0 mebibytes", binary.Format(0, units.Verbose(), units.ZeroSymbol("MiB")))
+	require.Equal(t, "-", binary.Format(0, units.Verbose(), units.ZeroPlaceholder("-")))
+
+	imperial := units.Unit[int64]{
+		{1, []string{"in"}, []string{"inch", "inches"}},
+		{12, []string{"ft"}, []string{"foot", "feet"}},
+		{36, []string{"yd"}, nil},
+	}
+
+	require.Equal(t, "1 foot 2 inches", imperial.Format(14, units.Verbose()))
+	require.Equal(t, "2 feet 1 inch", imperial.Format(25, units.Verbose()))
+	require.Equal(t, "1 yd 1 foot", imperial.Format(48, units.Verbose()))
+
+	for str, expected := range map[string]int64{
+		"1 foot 2 inches":        14,
+		"1 feet 2 inch":          14,
+		"1ft 2 inches":           14,
+		"2 feet1inch":            25,
+		"1 yd 1 foot 0.5 inches": 48,
+	} {
+		parsed, err := imperial.Parse(str)
+		require.NoError(t, err, str)
+		require.Equal(t, expected, parsed, str)
+	}
+}
+
+func TestFormat_MaxComponents(t *testing.T) {
+	testCases := []struct {
+		value    int64
+		n        int
+		expected string
+	}{
+		{1<<30 - 1, 0, "1023MiB1023KiB1023B"},
+		{1<<30 - 1, 1, "1GiB"},
+		{1<<30 - 1, 2, "1GiB"},
+		{1<<30 - 1, 3, "1023MiB1023KiB1023B"},
+		{1<<30 + 1<<29, 1, "2GiB"},
+		{1<<30 + 1<<29 - 1, 1, "1GiB"},
+		{1<<30 + 511<<20 + 1<<19, 2, "1GiB512MiB"},
+		{1<<30 + 5<<10 + 600, 2, "1GiB6KiB"},
+		{1<<30 + 5<<10 + 600, 3, "1GiB5KiB600B"},
+		{-(1<<30 - 1), 1, "-1GiB"},
+		{3, 1, "3B"},
+	}
+
+	for _, testCase := range testCases {
+		actual := binary.Format(testCase.value, units.MaxComponents(testCase.n))
+		require.Equal(t, testCase.expected, actual, "%d with %d components", testCase.value, testCase.n)
+	}
+
+	halves := units.Unit[int64]{{2, []string{"h"}, nil}, {4, []string{"q"}, nil}}
+	require.Equal(t, "1q0.5h", halves.Format(5, units.MaxComponents(2)))
+	require.Equal(t, "2q", halves.Format(7, units.MaxComponents(1)))
+
+	// larger symbols need not be multiples of smaller ones
+	coins := units.Unit[int64]{{1, []string{"p"}, nil}, {10, []string{"d"}, nil}, {25, []string{"q"}, nil}}
+	require.Equal(t, "1q1d", coins.Format(37, units.MaxComponents(2)))
+	require.Equal(t, "1q2d", coins.Format(43, units.MaxComponents(2)))
+
+	require.Equal(t, "-9223372036854775808B", units.Unit[int64]{{1, []string{"B"}, nil}}.Format(math.MinInt64, units.MaxComponents(1)))
+
+	// rounding never leaves the range of the type
+	small := units.Unit[int8]{{1, []string{"B"}, nil}, {16, []string{"X"}, nil}}
+	require.Equal(t, "7X", small.Format(math.MaxInt8, units.MaxComponents(1)))
+	require.Equal(t, "-8X", small.Format(math.MinInt8+1, units.MaxComponents(1)))
+}
+
+func TestFormat_Sign(t *testing.T) {
+	require.Equal(t, "-5GiB", binary.Format(-5<<30))
+	require.Equal(t, "-1GiB512MiB", binary.Format(-(1<<30 + 512<<20)))
+	require.Equal(t, "5GiB", binary.Format(5<<30))
+	require.Equal(t, "+5GiB", binary.Format(5<<30, units.ExplicitPlus()))
+	require.Equal(t, "-5GiB", binary.Format(-5<<30, units.ExplicitPlus()))
+
+	small := units.Unit[int8]{{1, []string{"B"}, nil}, {16, []string{"X"}, nil}}
+	require.Equal(t, "-8X", small.Format(math.MinInt8))
+	require.Equal(t, "7X15B", small.Format(math.MaxInt8))
+
+	for _, value := range []int64{math.MinInt64, math.MinInt64 + 1, -1, 1, math.MaxInt64, -(1<<40 + 1)} {
+		for _, opts := range [][]units.Option{nil, {units.ExplicitPlus()}} {
+			str := binary.Format(value, opts...)
+
+			parsed, err := binary.Parse(str)
+			require.NoError(t, err, str)
+			require.Equal(t, value, parsed, str)
+		}
+	}
+
+	parsed, err := small.Parse("-8X")
+	require.NoError(t, err)
+	require.Equal(t, int8(math.MinInt8), parsed)
+}
+
+func TestFormat_Unsigned(t *testing.T) {
+	unit := units.Unit[uint64]{
+		{1, []string{"B"}, []string{"byte"}},
+		{1 << 10, []string{"KiB"}, []string{"kibibyte"}},
+		{1 << 60, []string{"EiB"}, []string{"exbibyte"}},
+	}
+
+	require.Equal(t, "15EiB1125899906842623KiB1023B", unit.Format(math.MaxUint64))
+	require.Equal(t, "16EiB", unit.Format(math.MaxUint64, units.Largest(), units.Precision(0)))
+	require.Equal(t, "4EiB", unit.Format(1<<62-1<<9, units.MaxComponents(1)))
+	require.Equal(t, "15EiB", unit.Format(math.MaxUint64, units.MaxComponents(1)))
+	require.Equal(t, "+1KiB", unit.Format(1<<10, units.ExplicitPlus()))
+
+	for _, value := range []uint64{0, 1, 1<<60 + 1, math.MaxUint64} {
+		parsed, err := unit.Parse(unit.Format(value))
+		require.NoError(t, err)
+		require.Equal(t, value, parsed)
+	}
+
+	parsed, err := unit.Parse("+16EiB")
+	require.ErrorIs(t, err, units.ErrOverflow)
+	require.Equal(t, uint64(0), parsed)
+
+	_, err = unit.Parse("1KiB -1B")
+	require.ErrorIs(t, err, units.ErrValueDoesNotMatchPattern)
+
+	_, err = unit.Parse(" -1KiB")
+	require.ErrorIs(t, err, units.ErrNegative)
+
+	var parseErr *units.ParseError
+	require.ErrorAs(t, err, &parseErr)
+	require.Equal(t, 1, parseErr.Offset)
+	require.Equal(t, "-", parseErr.Token)
+}
+
+func TestParseError(t *testing.T) {
+	unit := units.Unit[int64]{
+		{1, []string{"B"}, nil},
+		{1024, []string{"KiB"}, nil},
+	}
+
+	testCases := []struct {
+		input  string
+		offset int
+		token  string
+		cause  error
+	}{
+		{"BAD", 0, "BAD", units.ErrValueDoesNotMatchPattern},
+		{"-", 1, "", units.ErrValueDoesNotMatchPattern},
+		{"1KiB-1B", 4, "-", units.ErrValueDoesNotMatchPattern},
+		{"  1KiB 100", 10, "", units.ErrMissingSymbol},
+		{"100DNE", 3, "DNE", units.ErrUnrecognizedSymbol},
+		{"1KiB 2 MiB", 7, "MiB", units.ErrUnrecognizedSymbol},
+		{"1.2.3B", 0, "1.2.3", units.ErrInvalidNumber},
+	}
+
+	for _, testCase := range testCases {
+		_, err := unit.Parse(testCase.input)
+		require.Error(t, err, testCase.input)
+		require.ErrorIs(t, err, testCase.cause, testCase.input)
+
+		var parseErr *units.ParseError
+		require.ErrorAs(t, err, &parseErr, testCase.input)
+		require.Equal(t, testCase.input, parseErr.Input)
+		require.Equal(t, testCase.offset, parseErr.Offset, testCase.input)
+		require.Equal(t, testCase.token, parseErr.Token, testCase.input)
+	}
+
+	_, err := unit.Parse("100DNE")
+	require.EqualError(t, err, `unrecognized symbol "DNE" at offset 3 of "100DNE"`)
+
+	size, err := unit.Parse(" -1KiB 512 B ")
+	require.NoError(t, err)
+	require.Equal(t, int64(-1536), size)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package unitstest provides helpers for testing packages that declare their own units of measure.
+package unitstest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mjpitz/units"
+)
+
+// Validate fails the test when any of the provided units are malformed. Each problem found is reported individually to
+// make it easier to track down the offending symbols.
+func Validate[T units.Number](t testing.TB, unit ...units.Unit[T]) {
+	t.Helper()
+
+	for i, u := range unit {
+		err := u.Validate()
+
+		var verr *units.ValidationError
+		switch {
+		case err == nil:
+		case errors.As(err, &verr):
+			for _, err := range verr.Errors {
+				t.Errorf("unit %d: %v", i, err)
+			}
+		default:
+			t.Errorf("unit %d: %v", i, err)
+		}
+	}
+}
+
+// RoundTrip fails the test when a label or name declared by the provided units cannot be parsed back into the size of
+// its symbol, or when formatting a value near a symbol (tersely or verbosely) produces text that cannot be parsed back
+// into the original value. Values that aren't whole multiples of the smallest symbol are included, which are expected
+// to be parsed back using the default RoundHalfEven rounding.
+func RoundTrip[T units.Number](t testing.TB, unit ...units.Unit[T]) {
+	t.Helper()
+
+	for i, u := range unit {
+		for _, symbol := range u {
+			inputs := map[string]T{
+				"1 " + symbol.Singular(): symbol.Size,
+				"3 " + symbol.Plural():   3 * symbol.Size,
+			}
+
+			for _, label := range symbol.Label {
+				inputs["1"+label] = symbol.Size
+				inputs["3 "+label] = 3 * symbol.Size
+				inputs["-1"+label+" 1"+u[0].Label[0]] = -symbol.Size - u[0].Size
+			}
+
+			for input, expected := range inputs {
+				parsed, err := u.Parse(input)
+				if err != nil {
+					t.Errorf("unit %d: failed to parse %q: %v", i, input, err)
+				} else if parsed != expected {
+					t.Errorf("unit %d: parsed %q as %v, expected %v", i, input, parsed, expected)
+				}
+			}
+
+			// values that aren't whole multiples of the smallest symbol are formatted with a decimal remainder
+			for _, value := range []T{3*symbol.Size + u[0].Size, 3*symbol.Size + 1, symbol.Size - 1, symbol.Size/3 + 1} {
+				for _, formatted := range []string{u.Format(value), u.Format(value, units.Verbose())} {
+					parsed, err := u.Parse(formatted)
+					if err != nil {
+						t.Errorf("unit %d: failed to parse %q: %v", i, formatted, err)
+					} else if parsed != value {
+						t.Errorf("unit %d: parsed %q as %v, expected %v", i, formatted, parsed, value)
+					}
+				}
+			}
+		}
+	}
+}
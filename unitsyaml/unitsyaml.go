@@ -0,0 +1,74 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package unitsyaml supports decoding quantity types from YAML documents using gopkg.in/yaml.v3 while reporting where
+// in the document a value failed to decode. The quantity types implement the obsolete form of yaml.Unmarshaler so that
+// they can be decoded without depending on a YAML package, which doesn't provide the position of the value. The
+// package is kept separate so that only programs using it depend on gopkg.in/yaml.v3.
+package unitsyaml
+
+import (
+	"fmt"
+
+	"github.com/mjpitz/units"
+	"gopkg.in/yaml.v3"
+)
+
+// Error reports a problem decoding a quantity from a YAML node along with the position of the node in the document.
+type Error struct {
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("yaml: line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Quantity wraps a value of a quantity type (for example, Quantity[data.Size]) so that errors decoding it are reported
+// as an *Error carrying the line and column of the offending node. The value is decoded and encoded using the YAML
+// methods of T, accepting scalar strings and integers and emitting the same format as String.
+type Quantity[T units.Number] struct {
+	Value T
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (q Quantity[T]) MarshalYAML() (interface{}, error) {
+	return q.Value, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Sequences and mappings are rejected.
+func (q *Quantity[T]) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode {
+		return &Error{node.Line, node.Column, fmt.Errorf("cannot unmarshal %s into %T", node.ShortTag(), q.Value)}
+	}
+
+	value := q.Value
+	if err := node.Decode(&value); err != nil {
+		return &Error{node.Line, node.Column, err}
+	}
+
+	q.Value = value
+	return nil
+}
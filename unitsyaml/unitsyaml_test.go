@@ -0,0 +1,118 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package unitsyaml_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/mjpitz/units"
+	"github.com/mjpitz/units/data"
+	"github.com/mjpitz/units/length"
+	"github.com/mjpitz/units/mass"
+	"github.com/mjpitz/units/network"
+	"github.com/mjpitz/units/unitsyaml"
+	"github.com/mjpitz/units/volume"
+)
+
+// roundTrip checks that the value is encoded as the provided text and that the text, the provided inputs, and the value
+// in its base unit (as an integer or a quoted string) decode back into it, both directly and through a Quantity.
+func roundTrip[T units.Number](t *testing.T, value T, text string, inputs ...string) {
+	t.Helper()
+
+	type config struct {
+		Value    T                     `yaml:"value"`
+		Quantity unitsyaml.Quantity[T] `yaml:"quantity"`
+	}
+
+	encoded, err := yaml.Marshal(config{value, unitsyaml.Quantity[T]{value}})
+	require.NoError(t, err)
+	require.Equal(t, "value: "+text+"\nquantity: "+text+"\n", string(encoded))
+
+	base := strconv.FormatInt(int64(value), 10)
+	for _, input := range append([]string{text, base, strconv.Quote(base)}, inputs...) {
+		var decoded config
+		require.NoError(t, yaml.Unmarshal([]byte("value: "+input+"\nquantity: "+input), &decoded), input)
+		require.Equal(t, value, decoded.Value, input)
+		require.Equal(t, value, decoded.Quantity.Value, input)
+	}
+}
+
+func TestQuantity(t *testing.T) {
+	roundTrip(t, 512*data.Megabyte, "512MB", `"0.512GB"`, "'512 megabytes'", "0x1E848000")
+	roundTrip(t, 1500*length.Meter, "1km5hm", "1.5 kilometers")
+	roundTrip(t, 72*mass.Kilogram+350*mass.Gram, "72kg3hg5dag", "72.35kg")
+	roundTrip(t, 10*network.Gibibit, "10Gibps", "10 gibibits per second")
+	roundTrip(t, 2*volume.Liter+500*volume.Milliliter, "2L5dL", "2.5L")
+}
+
+func TestQuantity_Errors(t *testing.T) {
+	type config struct {
+		Cache struct {
+			Size  unitsyaml.Quantity[data.Size]         `yaml:"size"`
+			Limit unitsyaml.Quantity[network.Bandwidth] `yaml:"limit"`
+		} `yaml:"cache"`
+	}
+
+	testCases := []struct {
+		document string
+		line     int
+		column   int
+		cause    error
+	}{
+		{"cache:\n  size: 1GiB\n  limit:   100DNE\n", 3, 12, units.ErrUnrecognizedSymbol},
+		{"cache:\n  size: 1GiB 2\n", 2, 9, units.ErrMissingSymbol},
+		{"cache:\n  size: [1GiB]\n", 2, 9, nil},
+		{"cache:\n  size:\n    value: 1GiB\n", 3, 5, nil},
+		{"cache:\n  size: 1.5\n", 2, 9, units.ErrMissingSymbol},
+		{"cache:\n  size: true\n", 2, 9, nil},
+	}
+
+	for _, testCase := range testCases {
+		var cfg config
+		err := yaml.Unmarshal([]byte(testCase.document), &cfg)
+		require.Error(t, err, testCase.document)
+
+		var yerr *unitsyaml.Error
+		require.ErrorAs(t, err, &yerr, testCase.document)
+		require.Equal(t, testCase.line, yerr.Line, testCase.document)
+		require.Equal(t, testCase.column, yerr.Column, testCase.document)
+
+		if testCase.cause != nil {
+			require.ErrorIs(t, err, testCase.cause, testCase.document)
+		}
+	}
+
+	// values are left untouched by null
+	var cfg config
+	cfg.Cache.Size.Value = data.Gibibyte
+	require.NoError(t, yaml.Unmarshal([]byte("cache:\n  size: null\n  limit: ~\n"), &cfg))
+	require.Equal(t, data.Gibibyte, cfg.Cache.Size.Value)
+
+	var plain struct {
+		Size data.Size `yaml:"size"`
+	}
+	require.Error(t, yaml.Unmarshal([]byte("size: 100DNE"), &plain))
+	require.ErrorIs(t, yaml.Unmarshal([]byte("size: 1.5"), &plain), units.ErrMissingSymbol)
+}
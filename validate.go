@@ -0,0 +1,204 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrEmptyUnit notifies the caller that a Unit without any symbols was provided.
+	ErrEmptyUnit = fmt.Errorf("unit has no symbols")
+
+	// ErrUnsorted notifies the caller that a symbol is not larger than the symbol that precedes it.
+	ErrUnsorted = fmt.Errorf("symbols are not sorted in ascending order")
+
+	// ErrInvalidSize notifies the caller that a symbol has a size that is zero or negative.
+	ErrInvalidSize = fmt.Errorf("symbol size must be positive")
+
+	// ErrMissingLabel notifies the caller that a symbol does not have any labels.
+	ErrMissingLabel = fmt.Errorf("symbol has no labels")
+
+	// ErrDuplicateLabel notifies the caller that a label is associated with more than one size.
+	ErrDuplicateLabel = fmt.Errorf("label is used by multiple sizes")
+
+	// ErrUnparsableLabel notifies the caller that a label can never be matched by Parse.
+	ErrUnparsableLabel = fmt.Errorf("label can never be parsed")
+
+	// ErrAmbiguousLabel notifies the caller that labels of different sizes cannot be told apart when case is ignored.
+	ErrAmbiguousLabel = fmt.Errorf("labels collide when case is ignored")
+)
+
+// SymbolError describes a problem with a single Symbol in a Unit.
+type SymbolError struct {
+	Index int
+	Label string
+	Err   error
+}
+
+func (e *SymbolError) Error() string {
+	if e.Label == "" {
+		return fmt.Sprintf("symbol %d: %v", e.Index, e.Err)
+	}
+
+	return fmt.Sprintf("symbol %d (%q): %v", e.Index, e.Label, e.Err)
+}
+
+func (e *SymbolError) Unwrap() error {
+	return e.Err
+}
+
+// CollisionError lists the labels that prevent a Unit from being parsed without regard to case. Each entry holds a
+// group of labels that only differ by case but belong to symbols of different sizes (for example, "mB" and "MB").
+type CollisionError struct {
+	Collisions [][]string
+}
+
+func (e *CollisionError) Error() string {
+	msgs := make([]string, 0, len(e.Collisions))
+	for _, labels := range e.Collisions {
+		quoted := make([]string, 0, len(labels))
+		for _, label := range labels {
+			quoted = append(quoted, strconv.Quote(label))
+		}
+
+		msgs = append(msgs, strings.Join(quoted, ", "))
+	}
+
+	return fmt.Sprintf("%v: %s", ErrAmbiguousLabel, strings.Join(msgs, "; "))
+}
+
+func (e *CollisionError) Unwrap() error {
+	return ErrAmbiguousLabel
+}
+
+// ValidationError contains all problems found while validating a Unit.
+type ValidationError struct {
+	Errors []error
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+
+	return "invalid unit: " + strings.Join(msgs, "; ")
+}
+
+// Is reports whether any of the contained errors match the target.
+func (e *ValidationError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sized extends a table with comparisons of the sizes of its symbols, allowing Unit and BigUnit to share the same
+// validation.
+type sized interface {
+	table
+	// positive reports whether the size of the i-th symbol is greater than zero.
+	positive(i int) bool
+	// less reports whether the size of the i-th symbol is smaller than the size of the j-th symbol.
+	less(i, j int) bool
+}
+
+func (u Unit[T]) positive(i int) bool {
+	return u[i].Size > 0
+}
+
+func (u Unit[T]) less(i, j int) bool {
+	return u[i].Size < u[j].Size
+}
+
+// Validate ensures the Unit is well-formed. Symbols must be sorted in ascending order by size, sizes must be positive,
+// each symbol must have at least one label, labels and names may not be shared by symbols of different sizes (including
+// those that only differ by their use of the micro sign or Greek letter mu), and every label and name must be something
+// Parse is capable of matching. When problems are found, a *ValidationError is returned containing a *SymbolError for
+// each of them.
+func (u Unit[T]) Validate() error {
+	return validate(u)
+}
+
+// validate checks the symbols of a Unit or BigUnit. See Unit.Validate.
+func validate(t sized) error {
+	if t.count() == 0 {
+		return ErrEmptyUnit
+	}
+
+	var errs []error
+
+	// symbols maps each spelling to the index of the last symbol using it
+	symbols := make(map[string]int)
+
+	for i := 0; i < t.count(); i++ {
+		labels, names := t.symbol(i)
+
+		label := ""
+		if len(labels) > 0 {
+			label = labels[0]
+		}
+
+		if !t.positive(i) {
+			errs = append(errs, &SymbolError{i, label, ErrInvalidSize})
+		}
+
+		if i > 0 && !t.less(i-1, i) {
+			errs = append(errs, &SymbolError{i, label, ErrUnsorted})
+		}
+
+		if len(labels) == 0 {
+			errs = append(errs, &SymbolError{i, label, ErrMissingLabel})
+		}
+
+		for _, label := range spellings(labels, names, nil) {
+			if !parsable(label) {
+				errs = append(errs, &SymbolError{i, label, ErrUnparsableLabel})
+			}
+
+			duplicate := false
+			for _, variant := range variants(label) {
+				if j, ok := symbols[variant]; ok && !t.same(i, j) {
+					duplicate = true
+				}
+
+				symbols[variant] = i
+			}
+
+			if duplicate {
+				errs = append(errs, &SymbolError{i, label, ErrDuplicateLabel})
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return &ValidationError{errs}
+	}
+
+	return nil
+}
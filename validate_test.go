@@ -0,0 +1,82 @@
+// Copyright (c) 2023 Mya Pitzeruse
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+// OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+// OR OTHER DEALINGS IN THE SOFTWARE.
+
+package units_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mjpitz/units"
+)
+
+func TestUnit_Validate(t *testing.T) {
+	require.NoError(t, binary.Validate())
+	require.ErrorIs(t, units.Unit[int64]{}.Validate(), units.ErrEmptyUnit)
+
+	testCases := []struct {
+		unit  units.Unit[int64]
+		index int
+		label string
+		cause error
+	}{
+		{units.Unit[int64]{{0, []string{"Z"}, nil}}, 0, "Z", units.ErrInvalidSize},
+		{units.Unit[int64]{{-1, []string{"N"}, nil}}, 0, "N", units.ErrInvalidSize},
+		{units.Unit[int64]{{1000, []string{"kB"}, nil}, {1, []string{"B"}, nil}}, 1, "B", units.ErrUnsorted},
+		{units.Unit[int64]{{1, []string{"B"}, nil}, {1, []string{"b"}, nil}}, 1, "b", units.ErrUnsorted},
+		{units.Unit[int64]{{1, []string{"B"}, nil}, {1000, nil, nil}}, 1, "", units.ErrMissingLabel},
+		{units.Unit[int64]{{1, []string{"B", "MB"}, nil}, {1000, []string{"MB"}, nil}}, 1, "MB", units.ErrDuplicateLabel},
+		{units.Unit[int64]{{1, []string{""}, nil}}, 0, "", units.ErrUnparsableLabel},
+		{units.Unit[int64]{{1, []string{" B"}, nil}}, 0, " B", units.ErrUnparsableLabel},
+		{units.Unit[int64]{{1, []string{"2B"}, nil}}, 0, "2B", units.ErrUnparsableLabel},
+		{units.Unit[int64]{{1, []string{".B"}, nil}}, 0, ".B", units.ErrUnparsableLabel},
+		{units.Unit[int64]{{1, []string{"_B"}, nil}}, 0, "_B", units.ErrUnparsableLabel},
+		{units.Unit[int64]{{1, []string{",B"}, nil}}, 0, ",B", units.ErrUnparsableLabel},
+		{units.Unit[int64]{{1, []string{"e5B"}, nil}}, 0, "e5B", units.ErrUnparsableLabel},
+		{units.Unit[int64]{{1, []string{"\u00b5m"}, nil}, {1000, []string{"\u03bcm"}, nil}}, 1, "\u03bcm", units.ErrDuplicateLabel},
+		{units.Unit[int64]{{1, []string{"B"}, []string{"byte", "bytes"}}, {1000, []string{"kB"}, []string{"kilobyte", "bytes"}}}, 1, "bytes", units.ErrDuplicateLabel},
+		{units.Unit[int64]{{1, []string{"B"}, []string{"byte"}}, {2, []string{"bytes"}, nil}}, 1, "bytes", units.ErrDuplicateLabel},
+		{units.Unit[int64]{{1, []string{"B"}, []string{"byte", " bytes"}}}, 0, " bytes", units.ErrUnparsableLabel},
+	}
+
+	for _, testCase := range testCases {
+		err := testCase.unit.Validate()
+		require.ErrorIs(t, err, testCase.cause)
+
+		var verr *units.ValidationError
+		require.ErrorAs(t, err, &verr)
+		require.Len(t, verr.Errors, 1, err.Error())
+
+		var serr *units.SymbolError
+		require.True(t, errors.As(verr.Errors[0], &serr))
+		require.Equal(t, testCase.index, serr.Index)
+		require.Equal(t, testCase.label, serr.Label)
+	}
+
+	err := units.Unit[int64]{{0, nil, nil}, {0, []string{"1"}, nil}}.Validate()
+	require.EqualError(t, err, `invalid unit: symbol 0: symbol size must be positive; symbol 0: symbol has no labels; `+
+		`symbol 1 ("1"): symbol size must be positive; symbol 1 ("1"): symbols are not sorted in ascending order; `+
+		`symbol 1 ("1"): label can never be parsed`)
+
+	_, err = units.Compile(units.Unit[int64]{{1, []string{"B"}, nil}, {1, []string{"b"}, nil}})
+	require.ErrorIs(t, err, units.ErrUnsorted)
+}
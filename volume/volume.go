@@ -21,6 +21,9 @@
 package volume
 
 import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
 	"sort"
 
 	"github.com/mjpitz/units"
@@ -36,8 +39,9 @@ func (u Volume) As(other Volume) float64 {
 	return float64(u) / float64(other)
 }
 
+// Set implements flag.Value, rounding fractions of a nanoliter half to even, as units.Unit.Parse does.
 func (u *Volume) Set(val string) error {
-	v, err := all.Parse(val)
+	v, err := codec.Parse(val)
 	if err != nil {
 		return err
 	}
@@ -50,6 +54,53 @@ func (u Volume) String() string {
 	return SI.Format(u)
 }
 
+// Format implements fmt.Formatter. See units.FormatValue for the supported verbs.
+func (u Volume) Format(f fmt.State, verb rune) {
+	units.FormatValue(f, verb, u, SI, constants)
+}
+
+// Scan implements fmt.Scanner, accepting the same values as Set.
+func (u *Volume) Scan(state fmt.ScanState, verb rune) error {
+	v, err := units.ScanValue(state, verb, codec)
+	if err != nil {
+		return err
+	}
+
+	*u = v
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler using the same format as String.
+func (u Volume) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting the same values as Set.
+func (u *Volume) UnmarshalText(text []byte) error {
+	return u.Set(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting either a string (see Set) or a bare integer number of nanoliters.
+func (u *Volume) UnmarshalJSON(data []byte) error {
+	return units.UnmarshalJSON(data, u, codec)
+}
+
+// MarshalYAML implements yaml.Marshaler, emitting the same format as String.
+func (u Volume) MarshalYAML() (interface{}, error) {
+	return u.String(), nil
+}
+
+// UnmarshalYAML implements the obsolete form of yaml.Unmarshaler, accepting a string (see Set) or a bare integer number
+// of nanoliters. Errors don't report where the value appears in the document (see unitsyaml.Quantity).
+func (u *Volume) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return units.UnmarshalYAML(unmarshal, u, codec)
+}
+
+// Value implements driver.Valuer, storing the value as an integer number of nanoliters (see units.SQL).
+func (u Volume) Value() (driver.Value, error) {
+	return int64(u), nil
+}
+
 const (
 	Nanoliter  Volume = 1
 	Microliter        = 1000 * Nanoliter
@@ -68,28 +119,79 @@ const (
 	Gallon     = 4 * Quart            // gal
 )
 
+// Megaliter returns the volume of a megaliter. Megaliter and larger volumes are only declared for BigSI since the
+// largest of them exceed the range of Volume. Each call returns a new *big.Int that the caller may modify.
+func Megaliter() *big.Int {
+	return new(big.Int).Mul(units.ToBig(Kiloliter), big.NewInt(1000))
+}
+
+// Gigaliter returns the volume of a gigaliter. See Megaliter.
+func Gigaliter() *big.Int {
+	return new(big.Int).Mul(Megaliter(), big.NewInt(1000))
+}
+
+// Teraliter returns the volume of a teraliter. See Megaliter.
+func Teraliter() *big.Int {
+	return new(big.Int).Mul(Gigaliter(), big.NewInt(1000))
+}
+
 var (
 	SI = units.Unit[Volume]{
-		{Nanoliter, []string{"nL"}},
-		{Microliter, []string{"μL", "uL"}},
-		{Milliliter, []string{"mL"}},
-		{Centiliter, []string{"cL"}},
-		{Deciliter, []string{"dL"}},
-		{Liter, []string{"L"}},
-		{Decaliter, []string{"daL"}},
-		{Hectoliter, []string{"hL"}},
-		{Kiloliter, []string{"kL"}},
+		{Nanoliter, []string{"nL"}, []string{"nanoliter"}},
+		{Microliter, []string{"μL", "uL"}, []string{"microliter"}},
+		{Milliliter, []string{"mL"}, []string{"milliliter"}},
+		{Centiliter, []string{"cL"}, []string{"centiliter"}},
+		{Deciliter, []string{"dL"}, []string{"deciliter"}},
+		{Liter, []string{"L"}, []string{"liter"}},
+		{Decaliter, []string{"daL"}, []string{"decaliter"}},
+		{Hectoliter, []string{"hL"}, []string{"hectoliter"}},
+		{Kiloliter, []string{"kL"}, []string{"kiloliter"}},
 	}
 
 	Imperial = units.Unit[Volume]{
-		{FluidOunce, []string{"fl oz"}},
-		{Gill, []string{"gi"}},
-		{Pint, []string{"pt"}},
-		{Quart, []string{"qt"}},
-		{Gallon, []string{"gal"}},
+		{FluidOunce, []string{"fl oz"}, []string{"fluid ounce"}},
+		{Gill, []string{"gi"}, []string{"gill"}},
+		{Pint, []string{"pt"}, []string{"pint"}},
+		{Quart, []string{"qt"}, []string{"quart"}},
+		{Gallon, []string{"gal"}, []string{"gallon"}},
 	}
 
-	all units.Unit[Volume]
+	// BigSI extends SI with volumes beyond the range of Volume.
+	BigSI = units.NewBigUnit(SI,
+		units.BigSymbol{Megaliter(), []string{"ML"}, []string{"megaliter"}},
+		units.BigSymbol{Gigaliter(), []string{"GL"}, []string{"gigaliter"}},
+		units.BigSymbol{Teraliter(), []string{"TL"}, []string{"teraliter"}},
+	)
+
+	// FloatSI measures continuous quantities (such as flow measurements) in fractional nanoliters. Like BigSI, it
+	// extends SI with volumes beyond the range of Volume.
+	FloatSI = append(units.NewFloatUnit[float64](SI),
+		units.Symbol[float64]{1e15, []string{"ML"}, []string{"megaliter"}},
+		units.Symbol[float64]{1e18, []string{"GL"}, []string{"gigaliter"}},
+		units.Symbol[float64]{1e21, []string{"TL"}, []string{"teraliter"}},
+	)
+	FloatImperial = units.NewFloatUnit[float64](Imperial)
+
+	all   units.Unit[Volume]
+	codec *units.Codec[Volume]
+
+	// constants maps sizes to the names of the constants declaring them for rendering Go syntax
+	constants = map[Volume]string{
+		Nanoliter:  "volume.Nanoliter",
+		Microliter: "volume.Microliter",
+		Milliliter: "volume.Milliliter",
+		Centiliter: "volume.Centiliter",
+		Deciliter:  "volume.Deciliter",
+		Liter:      "volume.Liter",
+		Decaliter:  "volume.Decaliter",
+		Hectoliter: "volume.Hectoliter",
+		Kiloliter:  "volume.Kiloliter",
+		FluidOunce: "volume.FluidOunce",
+		Gill:       "volume.Gill",
+		Pint:       "volume.Pint",
+		Quart:      "volume.Quart",
+		Gallon:     "volume.Gallon",
+	}
 )
 
 func init() {
@@ -101,4 +203,6 @@ func init() {
 	sort.Slice(all, func(i, j int) bool {
 		return all[i].Size < all[j].Size
 	})
+
+	codec = units.MustCompile(all)
 }
@@ -21,10 +21,14 @@
 package volume_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/mjpitz/units"
 	"github.com/mjpitz/units/volume"
+	"github.com/mjpitz/units/internal/quantitytest"
+	"github.com/mjpitz/units/unitstest"
 )
 
 func TestVolume(t *testing.T) {
@@ -52,7 +56,8 @@ func TestVolume(t *testing.T) {
 	require.Equal(t, "1μL", volume.Microliter.String())
 	require.Equal(t, "1nL", volume.Nanoliter.String())
 
-	require.Equal(t, "", volume.Imperial.Format(0))
+	require.Equal(t, "0fl oz", volume.Imperial.Format(0))
+	require.Equal(t, "0nL", volume.Volume(0).String())
 	require.Equal(t, "1gal", volume.Imperial.Format(volume.Gallon))
 	require.Equal(t, "1qt", volume.Imperial.Format(volume.Quart))
 	require.Equal(t, "1pt", volume.Imperial.Format(volume.Pint))
@@ -72,6 +77,7 @@ func TestVolume(t *testing.T) {
 		{"10kL", false, 10 * volume.Kiloliter},
 		{"1kL1hL1daL", false, volume.Kiloliter + volume.Hectoliter + volume.Decaliter},
 		{"100DNE", true, 0},
+		{"5", true, 0},
 		{"BAD", true, 0},
 	}
 
@@ -87,3 +93,71 @@ func TestVolume(t *testing.T) {
 		require.Equal(t, testCase.expected, basic)
 	}
 }
+
+func TestEncoding(t *testing.T) {
+	quantitytest.Encoding(t, 2*volume.Liter+500*volume.Milliliter, "2L5dL", "2.5L")
+}
+
+func TestSQL(t *testing.T) {
+	quantitytest.SQL(t, 2*volume.Liter+500*volume.Milliliter, "2L5dL", "2.5L")
+}
+
+func TestFormatter(t *testing.T) {
+	value := 1500 * volume.Milliliter
+
+	testCases := []struct {
+		format   string
+		expected string
+	}{
+		{"%v", "1L5dL"},
+		{"%.1v", "1.5L"},
+		{"%+v", "1 liter 5 deciliters"},
+		{"%#v", "volume.Deciliter*15"},
+		{"%d", "1500000000"},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, fmt.Sprintf(testCase.format, value), testCase.format)
+	}
+
+	require.Equal(t, "volume.Gallon", fmt.Sprintf("%#v", volume.Gallon))
+
+	var scanned volume.Volume
+	_, err := fmt.Sscanf("limit=1.5L", "limit=%v", &scanned)
+	require.NoError(t, err)
+	require.Equal(t, value, scanned)
+}
+
+func TestBig(t *testing.T) {
+	reservoir, err := volume.BigSI.Parse("2.5TL")
+	require.NoError(t, err)
+	require.Equal(t, "2TL500GL", volume.BigSI.Format(reservoir))
+
+	_, err = units.FromBig[volume.Volume](reservoir)
+	require.ErrorIs(t, err, units.ErrOverflow)
+
+	require.NoError(t, volume.BigSI.Validate())
+	require.Equal(t, "1TL", volume.BigSI.Format(volume.Teraliter()))
+}
+
+func TestFloat(t *testing.T) {
+	flow, err := volume.FloatSI.Parse("12.5 megaliters")
+	require.NoError(t, err)
+	require.Equal(t, 12.5e15, flow)
+	require.Equal(t, "12ML500kL", volume.FloatSI.Format(flow))
+	require.Equal(t, "12.5 megaliters", volume.FloatSI.Format(flow, units.Largest(), units.Verbose()))
+
+	liters, err := volume.FloatSI.As(flow, "L")
+	require.NoError(t, err)
+	require.Equal(t, 12.5e6, liters)
+
+	require.Equal(t, "1gal1qt", volume.FloatImperial.Format(1.25*float64(volume.Gallon)))
+
+	_, err = units.FromFloat[volume.Volume](flow*1e6, units.RoundTruncate)
+	require.ErrorIs(t, err, units.ErrOverflow)
+}
+
+func TestUnits(t *testing.T) {
+	unitstest.Validate(t, volume.SI, volume.Imperial)
+	unitstest.RoundTrip(t, volume.SI, volume.Imperial)
+}